@@ -1,14 +1,13 @@
 package main
 
 import (
-	"fmt"
 	"gogws/internal/commands"
+	"gogws/internal/exitcode"
 	"os"
 )
 
 func main() {
 	if err := commands.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitcode.Code(err))
 	}
 }
@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gogws/internal/engine"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/hooks"
+)
+
+type Options struct {
+	Parallel    int
+	StopOnError bool
+	Progress    func() (stop func())
+}
+
+type preHook func(workspaceRoot string, projects []string) (*hooks.Response, error)
+
+func Fetch(workspaceRoot string, projects []gws.Project, opts Options) (*engine.ExecuteResult, []git.RepoRefChanges, error) {
+	tracker := newRefTracker(projects)
+	result, err := pull(workspaceRoot, projects, opts, engine.ExecuteOptions{
+		OnStart:    tracker.before,
+		OnComplete: tracker.after,
+	}, "fetch", hooks.HookPreFetch, hooks.PreFetch, "fetch", "--all")
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, tracker.incoming(result), nil
+}
+
+func FF(workspaceRoot string, projects []gws.Project, opts Options) (*engine.ExecuteResult, error) {
+	return pull(workspaceRoot, projects, opts, engine.ExecuteOptions{}, "ff", hooks.HookPreFF, hooks.PreFF, "pull", "--ff-only")
+}
+
+func pull(workspaceRoot string, projects []gws.Project, opts Options, execOpts engine.ExecuteOptions, action string, hook hooks.HookType, pre preHook, args ...string) (*engine.ExecuteResult, error) {
+	commands := make([]engine.RepoCommand, 0, len(projects))
+	var skippedResults []engine.Result
+
+	for _, p := range projects {
+		repoPath := filepath.Join(workspaceRoot, p.Path)
+		cmd := engine.NewGitCommand(repoPath, p.Path, args...)
+		cmd = cmd.WithContext(export.ActionContextKey, action)
+
+		if !git.GetStatus(repoPath).Exists {
+			skippedResults = append(skippedResults, engine.Skip(cmd, "not cloned yet"))
+			continue
+		}
+
+		commands = append(commands, cmd)
+	}
+
+	names := make([]string, len(commands))
+	for i, c := range commands {
+		names[i] = c.RepoName
+	}
+
+	resp, err := pre(workspaceRoot, names)
+	if err != nil {
+		return nil, fmt.Errorf("%s hook failed: %w", hook, err)
+	}
+
+	commands, vetoed := resp.Filter(hook, commands)
+	skippedResults = append(skippedResults, vetoed...)
+
+	stopProgress := func() {}
+	if opts.Progress != nil {
+		stopProgress = opts.Progress()
+	}
+	execOpts.Parallel = opts.Parallel
+	execOpts.StopOnError = opts.StopOnError
+	execOpts.Settled = skippedResults
+	result := engine.Execute(commands, execOpts)
+	stopProgress()
+
+	return result, nil
+}
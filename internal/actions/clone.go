@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"path/filepath"
+
+	"gogws/internal/engine"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/hooks"
+)
+
+func CloneWorkspaces(workspaceRoot string, toClone []*gws.Workspace, resp *hooks.Response, opts Options) *engine.ExecuteResult {
+	if len(toClone) == 0 {
+		return engine.NewExecuteResult()
+	}
+
+	commands := make([]engine.RepoCommand, 0, len(toClone))
+
+	for _, child := range toClone {
+		remotes := []git.Remote{{Name: child.Remote.Name, URL: child.Remote.URL}}
+		childPath := child.Path
+
+		cmd := engine.NewCustomCommand(
+			filepath.Join(workspaceRoot, child.Path),
+			child.Path,
+			func() (string, error) {
+				return "", git.CloneWorkspace(workspaceRoot, childPath, remotes)
+			},
+		)
+		commands = append(commands, cmd.WithContext(export.ActionContextKey, "clone-workspace"))
+	}
+
+	return executeClones(commands, resp, opts)
+}
+
+func CloneProjects(workspaceRoot string, toClone []gws.Project, resp *hooks.Response, opts Options) *engine.ExecuteResult {
+	commands := make([]engine.RepoCommand, 0, len(toClone))
+
+	for _, p := range toClone {
+		remotes := toGitRemotes(p.Remotes)
+		projectPath := p.Path
+
+		cmd := engine.NewCustomCommand(
+			filepath.Join(workspaceRoot, p.Path),
+			p.Path,
+			func() (string, error) {
+				return "", git.CloneWorkspace(workspaceRoot, projectPath, remotes)
+			},
+		)
+		commands = append(commands, cmd.WithContext(export.ActionContextKey, "clone-project"))
+	}
+
+	return executeClones(commands, resp, opts)
+}
+
+func Merge(into, result *engine.ExecuteResult) {
+	into.Merge(result)
+}
+
+func executeClones(commands []engine.RepoCommand, resp *hooks.Response, opts Options) *engine.ExecuteResult {
+	commands, vetoed := resp.Filter(hooks.HookPreUpdate, commands)
+
+	return engine.Execute(commands, engine.ExecuteOptions{
+		Parallel:    opts.Parallel,
+		StopOnError: opts.StopOnError,
+		Settled:     vetoed,
+	})
+}
+
+func toGitRemotes(remotes []gws.Remote) []git.Remote {
+	result := make([]git.Remote, len(remotes))
+	for i, r := range remotes {
+		result[i] = git.Remote{Name: r.Name, URL: r.URL}
+	}
+	return result
+}
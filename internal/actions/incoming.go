@@ -0,0 +1,93 @@
+package actions
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"gogws/internal/cache"
+	"gogws/internal/engine"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+const maxIncomingCommits = 20
+
+type refTracker struct {
+	mu        sync.Mutex
+	defaults  map[string]string
+	snapshots map[string]map[string]string
+	changes   map[string][]git.RefChange
+}
+
+func newRefTracker(projects []gws.Project) *refTracker {
+	defaults := make(map[string]string, len(projects))
+	for _, p := range projects {
+		defaults[p.Path] = p.DefaultBranch
+	}
+	return &refTracker{
+		defaults:  defaults,
+		snapshots: make(map[string]map[string]string),
+		changes:   make(map[string][]git.RefChange),
+	}
+}
+
+func (t *refTracker) before(cmd engine.RepoCommand) {
+	refs, err := git.SnapshotRefs(cmd.RepoPath)
+	if err != nil {
+		slog.Debug("Failed to snapshot refs", "repo", cmd.RepoName, "error", err)
+		return
+	}
+
+	t.mu.Lock()
+	t.snapshots[cmd.RepoName] = refs
+	t.mu.Unlock()
+}
+
+func (t *refTracker) after(r engine.Result) {
+	t.mu.Lock()
+	before, ok := t.snapshots[r.Command.RepoName]
+	t.mu.Unlock()
+	if !r.Success {
+		return
+	}
+
+	git.SetRemoteHeads(r.Command.RepoPath, t.defaults[r.Command.RepoName])
+	if !ok {
+		return
+	}
+
+	after, err := git.SnapshotRefs(r.Command.RepoPath)
+	if err != nil {
+		slog.Debug("Failed to snapshot refs", "repo", r.Command.RepoName, "error", err)
+		return
+	}
+
+	_, defaultRef := git.DefaultBranch(r.Command.RepoPath, t.defaults[r.Command.RepoName])
+	if changes := git.DiffRefs(r.Command.RepoPath, before, after, defaultRef, maxIncomingCommits); len(changes) > 0 {
+		t.mu.Lock()
+		t.changes[r.Command.RepoName] = changes
+		t.mu.Unlock()
+	}
+}
+
+func (t *refTracker) incoming(result *engine.ExecuteResult) []git.RepoRefChanges {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var repos []git.RepoRefChanges
+	for _, r := range result.Results {
+		if changes, ok := t.changes[r.Command.RepoName]; ok {
+			repos = append(repos, git.RepoRefChanges{Repo: r.Command.RepoName, Changes: changes})
+		}
+	}
+	return repos
+}
+
+func RecordIncoming(workspaceRoot string, repos []git.RepoRefChanges) (cache.Incoming, error) {
+	incoming := cache.Incoming{
+		FetchedAt:    time.Now(),
+		Repositories: repos,
+	}
+	return incoming, cache.SaveIncoming(workspaceRoot, incoming)
+}
@@ -0,0 +1,43 @@
+package actions
+
+import (
+	"path/filepath"
+
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+func RemoteDrift(workspaceRoot string, project gws.Project, toManifest bool) ([]git.RemoteChange, []git.Remote, error) {
+	actual, err := git.GetRemotes(filepath.Join(workspaceRoot, project.Path))
+	if err != nil {
+		return nil, nil, err
+	}
+	if toManifest {
+		return git.CompareRemotes(actual, toGitRemotes(project.Remotes)), actual, nil
+	}
+	return git.CompareRemotes(toGitRemotes(project.Remotes), actual), actual, nil
+}
+
+func ManifestRemotes(project gws.Project, actual []git.Remote) []gws.Remote {
+	urls := make(map[string]string, len(actual))
+	for _, r := range actual {
+		urls[r.Name] = r.URL
+	}
+
+	remotes := make([]gws.Remote, 0, len(actual))
+	for _, r := range project.Remotes {
+		if url, ok := urls[r.Name]; ok {
+			if url != r.URL {
+				r = gws.Remote{Name: r.Name, URL: url}
+			}
+			remotes = append(remotes, r)
+			delete(urls, r.Name)
+		}
+	}
+	for _, r := range actual {
+		if _, ok := urls[r.Name]; ok {
+			remotes = append(remotes, gws.Remote{Name: r.Name, URL: r.URL})
+		}
+	}
+	return remotes
+}
@@ -0,0 +1,58 @@
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+func TestManifestRemotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		project []gws.Remote
+		actual  []git.Remote
+		want    []gws.Remote
+	}{
+		{
+			name:    "unchanged template keeps its spec",
+			project: []gws.Remote{{Name: "origin", URL: "https://git.example.com/api.git", Spec: "${GIT_BASE}/api.git"}},
+			actual:  []git.Remote{{Name: "origin", URL: "https://git.example.com/api.git"}},
+			want:    []gws.Remote{{Name: "origin", URL: "https://git.example.com/api.git", Spec: "${GIT_BASE}/api.git"}},
+		},
+		{
+			name:    "changed URL replaces the spec",
+			project: []gws.Remote{{Name: "origin", URL: "https://git.example.com/api.git", Spec: "${GIT_BASE}/api.git"}},
+			actual:  []git.Remote{{Name: "origin", URL: "git@other.example.com:api.git"}},
+			want:    []gws.Remote{{Name: "origin", URL: "git@other.example.com:api.git"}},
+		},
+		{
+			name: "keeps manifest order, drops removed and appends new remotes",
+			project: []gws.Remote{
+				{Name: "upstream", URL: "git@example.com:up/api.git"},
+				{Name: "gone", URL: "git@example.com:gone/api.git"},
+				{Name: "origin", URL: "git@example.com:api.git"},
+			},
+			actual: []git.Remote{
+				{Name: "fork", URL: "git@example.com:me/api.git"},
+				{Name: "origin", URL: "git@example.com:api.git"},
+				{Name: "upstream", URL: "git@example.com:up/api.git"},
+			},
+			want: []gws.Remote{
+				{Name: "upstream", URL: "git@example.com:up/api.git"},
+				{Name: "origin", URL: "git@example.com:api.git"},
+				{Name: "fork", URL: "git@example.com:me/api.git"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ManifestRemotes(gws.Project{Path: "api", Remotes: tt.project}, tt.actual)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ManifestRemotes() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
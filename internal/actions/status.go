@@ -0,0 +1,109 @@
+package actions
+
+import (
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"gogws/internal/cache"
+	"gogws/internal/engine"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+func Status(workspaceRoot string, projects []gws.Project, parallel int, c *cache.Cache) []git.RepositoryStatus {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	var mu sync.Mutex
+	statusMap := make(map[string]git.RepositoryStatus)
+
+	commands := make([]engine.RepoCommand, 0, len(projects))
+
+	for _, p := range projects {
+		repoPath := filepath.Join(workspaceRoot, p.Path)
+		projectPath := p.Path
+		defaultBranch := p.DefaultBranch
+
+		cmd := engine.NewCustomCommand(
+			repoPath,
+			projectPath,
+			func() (string, error) {
+				status, hit := cachedStatus(c, repoPath, projectPath, defaultBranch)
+				status.Cached = hit
+
+				mu.Lock()
+				statusMap[projectPath] = status
+				mu.Unlock()
+				return "", nil
+			},
+		)
+		commands = append(commands, cmd.WithContext(export.ActionContextKey, "status"))
+	}
+
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: parallel})
+
+	statuses := make([]git.RepositoryStatus, 0, len(projects))
+	for _, r := range result.Results {
+		if status, ok := statusMap[r.Command.RepoName]; ok {
+			statuses = append(statuses, status)
+		} else {
+			statuses = append(statuses, git.RepositoryStatus{
+				Path:   r.Command.RepoName,
+				Exists: false,
+				Error:  r.Error,
+			})
+		}
+	}
+
+	return statuses
+}
+
+func cachedStatus(c *cache.Cache, repoPath, projectPath, defaultBranch string) (git.RepositoryStatus, bool) {
+	if c == nil {
+		return fetchStatus(repoPath, projectPath, defaultBranch), false
+	}
+
+	ignored, known := c.Ignored(projectPath)
+	fingerprint := ""
+	if known {
+		fp, err := cache.Fingerprint(repoPath, ignored)
+		if err != nil {
+			return fetchStatus(repoPath, projectPath, defaultBranch), false
+		}
+		fingerprint = fp + "|" + defaultBranch
+
+		if status, ok := c.Get(projectPath, fingerprint); ok {
+			slog.Debug("Using cached status", "project", projectPath)
+			return status, true
+		}
+	}
+
+	current, err := git.IgnoredDirs(repoPath)
+	if err != nil {
+		return fetchStatus(repoPath, projectPath, defaultBranch), false
+	}
+	if !known || !slices.Equal(current, ignored) {
+		fp, err := cache.Fingerprint(repoPath, current)
+		if err != nil {
+			return fetchStatus(repoPath, projectPath, defaultBranch), false
+		}
+		fingerprint = fp + "|" + defaultBranch
+	}
+
+	status := fetchStatus(repoPath, projectPath, defaultBranch)
+	if status.Exists && status.Error == nil {
+		c.Put(projectPath, fingerprint, current, status)
+	}
+
+	return status, false
+}
+
+func fetchStatus(repoPath, projectPath, defaultBranch string) git.RepositoryStatus {
+	status := git.GetStatusWithDefault(repoPath, defaultBranch)
+	status.Path = projectPath
+	return status
+}
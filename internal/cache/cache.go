@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+const (
+	DirName  = "cache"
+	fileName = "status.json"
+	version  = 3
+)
+
+type entry struct {
+	Fingerprint string               `json:"fingerprint"`
+	Ignored     []string             `json:"ignored,omitempty"`
+	Status      git.RepositoryStatus `json:"status"`
+	CachedAt    time.Time            `json:"cached_at"`
+}
+
+type file struct {
+	Version int               `json:"version"`
+	Entries map[string]*entry `json:"entries"`
+}
+
+type Cache struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]*entry
+	touched map[string]bool
+}
+
+func Dir(workspaceRoot string) string {
+	return filepath.Join(workspaceRoot, gws.ConfigDirName, DirName)
+}
+
+func Load(workspaceRoot string) *Cache {
+	c := &Cache{
+		path:    filepath.Join(Dir(workspaceRoot), fileName),
+		entries: make(map[string]*entry),
+		touched: make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil || f.Version != version {
+		return c
+	}
+	for path, e := range f.Entries {
+		if e != nil {
+			c.entries[path] = e
+		}
+	}
+
+	return c
+}
+
+func (c *Cache) Get(path, fingerprint string) (git.RepositoryStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok || e.Fingerprint != fingerprint {
+		return git.RepositoryStatus{}, false
+	}
+
+	c.touched[path] = true
+	return e.Status, true
+}
+
+func (c *Cache) Ignored(path string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	return e.Ignored, true
+}
+
+func (c *Cache) Put(path, fingerprint string, ignored []string, status git.RepositoryStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[path] = &entry{
+		Fingerprint: fingerprint,
+		Ignored:     ignored,
+		Status:      status,
+		CachedAt:    time.Now(),
+	}
+	c.touched[path] = true
+}
+
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	f := file{Version: version, Entries: make(map[string]*entry, len(c.touched))}
+	for path := range c.touched {
+		f.Entries[path] = c.entries[path]
+	}
+	c.mu.Unlock()
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status cache: %w", err)
+	}
+
+	if err := writeFile(c.path, data); err != nil {
+		return fmt.Errorf("failed to write status cache: %w", err)
+	}
+
+	return nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func Clear(workspaceRoot string) (bool, error) {
+	dir := Dir(workspaceRoot)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return false, fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	return true, nil
+}
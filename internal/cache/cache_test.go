@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"gogws/internal/git"
+)
+
+func TestFingerprintChangesWithRepo(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git", "refs", "heads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(repo, "nested", ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := Fingerprint(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "nested", "file.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if fp, _ := Fingerprint(repo, nil); fp != first {
+		t.Error("changes inside a nested repository should not affect the fingerprint")
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, ".git", "refs", "heads", "main"), []byte("abc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := Fingerprint(repo, nil)
+	if second == first {
+		t.Error("new ref should change the fingerprint")
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	third, _ := Fingerprint(repo, nil)
+	if third == second {
+		t.Error("new worktree file should change the fingerprint")
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, ".git", "index"), []byte("staged"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if fp, _ := Fingerprint(repo, nil); fp == third {
+		t.Error("index change should change the fingerprint")
+	}
+}
+
+func TestFingerprintOnlyStatsDirectories(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(repo, "src", "main.go")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := Fingerprint(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if fp, _ := Fingerprint(repo, nil); fp != first {
+		t.Error("files in the working tree should not be stat'ed")
+	}
+
+	if err := os.Chtimes(filepath.Dir(file), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if fp, _ := Fingerprint(repo, nil); fp == first {
+		t.Error("directory mtime change should change the fingerprint")
+	}
+}
+
+func BenchmarkFingerprint(b *testing.B) {
+	repo := b.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git", "refs", "heads"), 0755); err != nil {
+		b.Fatal(err)
+	}
+	for d := 0; d < 50; d++ {
+		dir := filepath.Join(repo, "pkg", strconv.Itoa(d))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatal(err)
+		}
+		for f := 0; f < 200; f++ {
+			if err := os.WriteFile(filepath.Join(dir, strconv.Itoa(f)+".go"), nil, 0644); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Fingerprint(repo, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFingerprintSkipsIgnoredDirs(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(repo, "build", "out"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ignored := []string{"build"}
+	first, err := Fingerprint(repo, ignored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "build", "out", "app.bin"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if fp, _ := Fingerprint(repo, ignored); fp != first {
+		t.Error("changes inside an ignored directory should not affect the fingerprint")
+	}
+}
+
+func TestFingerprintRequiresGitDir(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, ".git"), []byte("gitdir: elsewhere\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Fingerprint(repo, nil); err == nil {
+		t.Error("expected error for a .git file")
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	root := t.TempDir()
+
+	c := Load(root)
+	c.Put("api", "fp1", nil, git.RepositoryStatus{Path: "api", Exists: true, Branch: "main"})
+	c.Put("web", "fp2", []string{"node_modules"}, git.RepositoryStatus{Path: "web", Exists: true})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = Load(root)
+	if _, ok := c.Get("api", "other"); ok {
+		t.Error("stale fingerprint should miss")
+	}
+	status, ok := c.Get("web", "fp2")
+	if !ok || status.Path != "web" {
+		t.Errorf("expected cached web status, got %+v (hit=%v)", status, ok)
+	}
+	if ignored, ok := c.Ignored("web"); !ok || len(ignored) != 1 || ignored[0] != "node_modules" {
+		t.Errorf("expected ignored directories to round-trip, got %v (found=%v)", ignored, ok)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = Load(root)
+	if _, ok := c.Get("api", "fp1"); ok {
+		t.Error("entries not used in the previous run should be dropped")
+	}
+
+	removed, err := Clear(root)
+	if err != nil || !removed {
+		t.Fatalf("Clear() = %v, %v", removed, err)
+	}
+	if _, ok := Load(root).Get("web", "fp2"); ok {
+		t.Error("cache should be empty after Clear")
+	}
+}
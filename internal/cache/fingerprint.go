@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+var gitFiles = []string{"HEAD", "index", "packed-refs", "config", filepath.Join("info", "exclude")}
+
+func Fingerprint(repoDir string, ignored []string) (string, error) {
+	gitDir := filepath.Join(repoDir, ".git")
+
+	info, err := os.Stat(gitDir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a git directory", gitDir)
+	}
+
+	h := sha256.New()
+
+	for _, name := range gitFiles {
+		writeStat(h, name, filepath.Join(gitDir, name))
+	}
+
+	err = filepath.WalkDir(filepath.Join(gitDir, "refs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(gitDir, path)
+		writeEntry(h, rel, d)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = filepath.WalkDir(repoDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return nil
+			}
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(repoDir, path)
+		if path != repoDir {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+				return filepath.SkipDir
+			}
+			if slices.Contains(ignored, filepath.ToSlash(rel)) {
+				return filepath.SkipDir
+			}
+		}
+
+		writeEntry(h, rel, d)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeStat(h hash.Hash, name, path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		fmt.Fprintf(h, "%s\x00-\n", name)
+		return
+	}
+	fmt.Fprintf(h, "%s\x00%d\x00%d\n", name, info.ModTime().UnixNano(), info.Size())
+}
+
+func writeEntry(h hash.Hash, rel string, d fs.DirEntry) {
+	info, err := d.Info()
+	if err != nil {
+		fmt.Fprintf(h, "%s\x00-\n", rel)
+		return
+	}
+	fmt.Fprintf(h, "%s\x00%o\x00%d\x00%d\n", rel, info.Mode(), info.ModTime().UnixNano(), info.Size())
+}
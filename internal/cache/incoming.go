@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gogws/internal/git"
+)
+
+const incomingFileName = "incoming.json"
+
+type Incoming struct {
+	FetchedAt    time.Time            `json:"fetched_at"`
+	Repositories []git.RepoRefChanges `json:"repositories"`
+}
+
+func SaveIncoming(workspaceRoot string, incoming Incoming) error {
+	data, err := json.MarshalIndent(incoming, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode incoming report: %w", err)
+	}
+
+	if err := writeFile(filepath.Join(Dir(workspaceRoot), incomingFileName), data); err != nil {
+		return fmt.Errorf("failed to write incoming report: %w", err)
+	}
+
+	return nil
+}
+
+func LoadIncoming(workspaceRoot string) (*Incoming, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(workspaceRoot), incomingFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read incoming report: %w", err)
+	}
+
+	var incoming Incoming
+	if err := json.Unmarshal(data, &incoming); err != nil {
+		return nil, fmt.Errorf("failed to parse incoming report: %w", err)
+	}
+
+	return &incoming, nil
+}
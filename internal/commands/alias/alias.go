@@ -0,0 +1,188 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	GroupID       = "aliases"
+	AnnotationKey = "help:alias"
+	stackEnvVar   = "GOGWS_ALIAS_STACK"
+)
+
+func Register(rootCmd *cobra.Command, args []string) {
+	if path := userConfigFlag(args); path != "" {
+		config.SetUserConfigPath(path)
+	}
+
+	workspaceRoot := ""
+	if wsInfo, err := gws.FindRoot(); err == nil {
+		workspaceRoot = wsInfo.Root
+	}
+
+	aliases, err := config.ResolveAliases(workspaceRoot)
+	if err != nil {
+		slog.Warn("Failed to load aliases", "err", err)
+		return
+	}
+	if len(aliases) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rootCmd.AddGroup(&cobra.Group{ID: GroupID, Title: "Aliases"})
+
+	for _, name := range names {
+		alias := aliases[name]
+		if isBuiltin(rootCmd, name) {
+			slog.Debug("Ignoring alias that shadows a built-in command", "alias", name, "origin", alias.Origin())
+			continue
+		}
+		rootCmd.AddCommand(newCommand(name, alias))
+	}
+}
+
+func newCommand(name string, alias config.ConfigValue[config.Alias]) *cobra.Command {
+	return &cobra.Command{
+		Use:                name,
+		Short:              fmt.Sprintf("Alias for '%s'", alias.Value.String()),
+		GroupID:            GroupID,
+		DisableFlagParsing: true,
+		Annotations: map[string]string{
+			AnnotationKey: fmt.Sprintf("%s\n(defined in %s)", strings.Join(alias.Value, "\n"), alias.Origin()),
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(name, alias.Value, args)
+		},
+	}
+}
+
+func isBuiltin(rootCmd *cobra.Command, name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
+
+func run(name string, alias config.Alias, extra []string) error {
+	stack := os.Getenv(stackEnvVar)
+	for _, entry := range strings.Split(stack, ",") {
+		if entry == name {
+			return fmt.Errorf("alias %s expands to itself (%s -> %s)", name, strings.ReplaceAll(stack, ",", " -> "), name)
+		}
+	}
+	if stack != "" {
+		stack += ","
+	}
+	stack += name
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate gogws executable: %w", err)
+	}
+
+	for i, step := range alias {
+		args, err := Split(step)
+		if err != nil {
+			return fmt.Errorf("alias %s: invalid step %q: %w", name, step, err)
+		}
+		if len(args) > 0 && args[0] == "gogws" {
+			args = args[1:]
+		}
+		args = append(args, extra...)
+
+		slog.Debug("Running alias step", "alias", name, "step", i+1, "args", args)
+
+		cmd := exec.Command(executable, args...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = output.Stdout()
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", stackEnvVar, stack))
+
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				if len(alias) == 1 {
+					return exitcode.Silent(exitErr.ExitCode())
+				}
+				return exitcode.New(exitErr.ExitCode(), fmt.Errorf("alias %s: step %d/%d (%s) failed: %w", name, i+1, len(alias), step, err))
+			}
+			if len(alias) == 1 {
+				return fmt.Errorf("alias %s failed: %w", name, err)
+			}
+			return fmt.Errorf("alias %s: step %d/%d (%s) failed: %w", name, i+1, len(alias), step, err)
+		}
+	}
+
+	return nil
+}
+
+func userConfigFlag(args []string) string {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--config="); ok {
+			return value
+		}
+		if arg == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func Split(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
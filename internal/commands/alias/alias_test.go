@@ -0,0 +1,132 @@
+package alias
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+)
+
+const fakeLogEnvVar = "GOGWS_ALIAS_TEST_LOG"
+
+func TestMain(m *testing.M) {
+	if logPath := os.Getenv(fakeLogEnvVar); logPath != "" {
+		os.Exit(fakeGogws(logPath, os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+func fakeGogws(logPath string, args []string) int {
+	log, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 2
+	}
+	defer log.Close()
+	fmt.Fprintln(log, strings.Join(args, " "))
+
+	switch args[0] {
+	case "fail":
+		return 3
+	case "loop":
+		if err := run("loop", config.Alias{"loop"}, nil); err != nil {
+			fmt.Fprintln(log, err)
+			return 1
+		}
+	}
+	return 0
+}
+
+func fakeLog(t *testing.T) string {
+	t.Helper()
+	logPath := filepath.Join(t.TempDir(), "steps.log")
+	t.Setenv(fakeLogEnvVar, logPath)
+	return logPath
+}
+
+func readLog(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"status --only-changes", []string{"status", "--only-changes"}, false},
+		{"  fetch   --parallel  2 ", []string{"fetch", "--parallel", "2"}, false},
+		{`commit -m "fix: shared message"`, []string{"commit", "-m", "fix: shared message"}, false},
+		{`log --grep 'a b' --author=""`, []string{"log", "--grep", "a b", "--author="}, false},
+		{`status --format "json`, nil, true},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Split(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunChain(t *testing.T) {
+	logPath := fakeLog(t)
+
+	if err := run("sync", config.Alias{"fetch", "gogws status --only-changes"}, []string{"-v"}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	want := []string{"fetch -v", "status --only-changes -v"}
+	if got := readLog(t, logPath); !reflect.DeepEqual(got, want) {
+		t.Errorf("steps = %q, want %q", got, want)
+	}
+}
+
+func TestRunStopsOnFirstFailure(t *testing.T) {
+	logPath := fakeLog(t)
+
+	err := run("deploy", config.Alias{"fail", "push"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "step 1/2 (fail) failed") {
+		t.Fatalf("expected step 1 failure, got %v", err)
+	}
+	if code := exitcode.Code(err); code != 3 {
+		t.Errorf("exit code = %d, want 3", code)
+	}
+	if got := readLog(t, logPath); !reflect.DeepEqual(got, []string{"fail"}) {
+		t.Errorf("steps after a failure should not run, got %q", got)
+	}
+}
+
+func TestRunDetectsCycle(t *testing.T) {
+	logPath := fakeLog(t)
+
+	if err := run("loop", config.Alias{"loop"}, nil); err == nil {
+		t.Fatal("expected a self-referencing alias to fail")
+	}
+
+	got := readLog(t, logPath)
+	want := []string{"loop", "alias loop expands to itself (loop -> loop)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+
+	t.Setenv(stackEnvVar, "deploy,loop")
+	if err := run("loop", config.Alias{"status"}, nil); err == nil || !strings.Contains(err.Error(), "deploy -> loop -> loop") {
+		t.Errorf("expected cycle error from the alias stack, got %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package apply
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	from    string
+	branch  string
+	commit  bool
+	message string
+	dryRun  bool
+)
+
+type messageData struct {
+	Repo    string
+	Source  string
+	Subject string
+	Message string
+}
+
+type plan struct {
+	patchPath string
+	source    *git.SourceCommit
+	label     string
+	subject   string
+	body      string
+	branch    string
+	message   *template.Template
+}
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <patch-file|commit-ish> [project...]",
+		Short: "Apply a patch or commit to many repositories",
+		Long: `Apply a patch file, or a commit taken from another project with --from,
+to every repository (or the given projects). Each repository gets a new
+branch and the change is applied with git apply --3way. Results are
+reported as:
+
+  applied          applied cleanly (staged, or committed with --commit)
+  conflicts        applied with conflict markers, left for manual resolution
+  not-applicable   the patch does not fit; the branch is removed again
+
+Repositories with uncommitted changes are skipped. --dry-run only runs
+git apply --check and changes nothing.
+
+The --message template can use {{.Repo}}, {{.Source}}, {{.Subject}} and
+{{.Message}}, the full original commit message (or the patch subject). It
+defaults to {{.Message}}.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(getConfig, args[0], args[1:])
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "take the commit-ish from this project instead of reading a patch file")
+	cmd.Flags().StringVarP(&branch, "branch", "b", "", "name of the branch to create (default apply/<source>)")
+	cmd.Flags().BoolVar(&commit, "commit", false, "commit cleanly applied changes")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "commit message template for --commit")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only check whether the patch applies")
+
+	return cmd
+}
+
+func runApply(getConfig func() *config.Config, sourceArg string, projectArgs []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running apply command", "workspace", cfg.WorkspaceRoot, "source", sourceArg)
+
+	out, err := output.NewForData(cfg.Format, "apply")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, projectArgs)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	p, cleanup, err := newPlan(cfg.WorkspaceRoot, ws.Projects, sourceArg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	results := make([]git.ApplyResult, len(projects))
+	commands := make([]engine.RepoCommand, 0, len(projects))
+
+	for i, project := range projects {
+		repoPath := filepath.Join(cfg.WorkspaceRoot, project.Path)
+		results[i] = git.ApplyResult{Repo: project.Path, Outcome: git.ApplySkipped}
+
+		switch reason := skipReason(project, repoPath); {
+		case reason != "":
+			results[i].Reason = reason
+			continue
+		case p.source != nil && project.Path == from:
+			results[i].Reason = "source of the commit"
+			continue
+		}
+
+		commands = append(commands, engine.NewCustomCommand(repoPath, project.Path, func() (string, error) {
+			results[i] = p.apply(repoPath, project.Path)
+			if results[i].Outcome == git.ApplyFailed {
+				return "", fmt.Errorf("%s", results[i].Reason)
+			}
+			return string(results[i].Outcome), nil
+		}))
+	}
+
+	stopProgress := out.Progress()
+	engine.Execute(commands, engine.ExecuteOptions{Parallel: cfg.Parallel})
+	stopProgress()
+
+	report := export.NewApplyOutput(p.label, p.branch, dryRun, results)
+
+	if !out.IsText() {
+		if err := out.Data("apply", report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(output.Stdout(), out.Renderer().RenderApply(results, dryRun))
+	}
+
+	if report.Conflicts > 0 || report.Failed > 0 {
+		return exitcode.New(exitcode.PartialFailure, fmt.Errorf("apply: %d with conflicts, %d failed of %d repositories", report.Conflicts, report.Failed, report.Total))
+	}
+	return nil
+}
+
+func skipReason(project gws.Project, repoPath string) string {
+	if !project.Exists {
+		return "not cloned yet"
+	}
+	if dryRun {
+		return ""
+	}
+	dirty, err := git.HasChangesToCommit(repoPath, true)
+	switch {
+	case err != nil:
+		return err.Error()
+	case dirty:
+		return "uncommitted changes"
+	}
+	return ""
+}
+
+func newPlan(workspaceRoot string, projects []gws.Project, sourceArg string) (*plan, func(), error) {
+	p := &plan{}
+	cleanup := func() {}
+
+	if from != "" {
+		source, err := gws.SelectProjects(projects, []string{from})
+		if err != nil {
+			return nil, nil, exitcode.New(exitcode.Usage, err)
+		}
+
+		p.source, err = git.ResolveCommit(filepath.Join(workspaceRoot, source[0].Path), sourceArg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		patch, err := git.FormatPatch(p.source)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tmp, err := os.CreateTemp("", "gogws-apply-*.patch")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to write patch: %w", err)
+		}
+		cleanup = func() { os.Remove(tmp.Name()) }
+		if _, err := tmp.Write(patch); err != nil {
+			tmp.Close()
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to write patch: %w", err)
+		}
+		tmp.Close()
+
+		p.patchPath = tmp.Name()
+		p.label = from + "@" + p.source.Hash[:7]
+		p.subject, _, _ = strings.Cut(p.source.Message, "\n")
+		p.branch = "apply/" + p.source.Hash[:7]
+	} else {
+		abs, err := filepath.Abs(sourceArg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve %s: %w", sourceArg, err)
+		}
+		patch, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, nil, exitcode.New(exitcode.Usage, fmt.Errorf("failed to read patch (use --from <project> to apply a commit): %w", err))
+		}
+
+		p.patchPath = abs
+		p.label = sourceArg
+		p.subject = git.PatchSubject(patch)
+		if p.subject == "" {
+			p.subject = "Apply " + filepath.Base(abs)
+		}
+		p.branch = "apply/" + branchSlug(strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs)))
+	}
+
+	if branch != "" {
+		p.branch = branch
+	}
+
+	p.body = p.subject
+	if p.source != nil {
+		p.body = p.source.Message
+	}
+
+	text := message
+	if text == "" {
+		text = "{{.Message}}"
+	}
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		cleanup()
+		return nil, nil, exitcode.New(exitcode.Usage, fmt.Errorf("invalid message template: %w", err))
+	}
+	p.message = tmpl
+
+	return p, cleanup, nil
+}
+
+func (p *plan) apply(repoPath, repo string) git.ApplyResult {
+	result := git.ApplyResult{Repo: repo}
+	fail := func(err error) git.ApplyResult {
+		result.Outcome, result.Reason = git.ApplyFailed, err.Error()
+		return result
+	}
+
+	if dryRun {
+		result.Outcome = git.ApplyClean
+		if err := git.CheckPatch(repoPath, p.patchPath); err != nil {
+			result.Outcome, result.Reason = git.ApplyNotApplicable, err.Error()
+		}
+		return result
+	}
+
+	if p.source != nil {
+		if err := git.SeedBlobs(p.source, repoPath); err != nil {
+			return fail(err)
+		}
+	}
+
+	original, err := git.CurrentBranch(repoPath)
+	if err != nil {
+		return fail(err)
+	}
+	if err := git.CreateBranch(repoPath, p.branch); err != nil {
+		return fail(err)
+	}
+	result.Branch = p.branch
+
+	outcome, conflicts, err := git.ApplyPatch(repoPath, p.patchPath)
+	result.Outcome, result.Conflicts = outcome, conflicts
+
+	switch outcome {
+	case git.ApplyFailed:
+		return fail(err)
+	case git.ApplyNotApplicable:
+		result.Reason, result.Branch = err.Error(), ""
+		if err := git.DropBranch(repoPath, p.branch, original); err != nil {
+			return fail(err)
+		}
+	case git.ApplyClean:
+		if !commit {
+			break
+		}
+		var msg strings.Builder
+		if err := p.message.Execute(&msg, messageData{Repo: repo, Source: p.label, Subject: p.subject, Message: p.body}); err != nil {
+			return fail(fmt.Errorf("failed to render message: %w", err))
+		}
+		author := ""
+		if p.source != nil {
+			author = p.source.Author
+		}
+		if result.Commit, err = git.CommitIndex(repoPath, msg.String(), author); err != nil {
+			return fail(err)
+		}
+	}
+
+	return result
+}
+
+var unsafeBranchChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func branchSlug(name string) string {
+	slug := strings.Trim(unsafeBranchChars.ReplaceAllString(name, "-"), "-.")
+	if slug == "" {
+		return "patch"
+	}
+	return slug
+}
@@ -0,0 +1,16 @@
+package apply
+
+import "testing"
+
+func TestBranchSlug(t *testing.T) {
+	tests := map[string]string{
+		"0001-Fix-license-header": "0001-Fix-license-header",
+		"bump deps (go 1.25)":     "bump-deps-go-1.25",
+		"..":                      "patch",
+	}
+	for in, want := range tests {
+		if got := branchSlug(in); got != want {
+			t.Errorf("branchSlug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package branches
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	merged    bool
+	gone      bool
+	staleDays int
+	yes       bool
+	dryRun    bool
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branches",
+		Short: "Manage local branches across the workspace",
+	}
+
+	prune := &cobra.Command{
+		Use:   "prune [project...]",
+		Short: "Delete merged and stale local branches",
+		Long: `Find local branches that are merged into the repository's default branch,
+whose upstream branch is gone, or (with --stale-days) that have no commits
+in the given number of days. The candidates are shown per repository and
+deleted after confirmation.
+
+The default branch, the checked-out branch and branches with commits that
+are not on any remote are never deleted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPrune(getConfig, args)
+		},
+	}
+
+	prune.Flags().BoolVar(&merged, "merged", true, "prune branches merged into the default branch")
+	prune.Flags().BoolVar(&gone, "gone", true, "prune branches whose upstream no longer exists")
+	prune.Flags().IntVar(&staleDays, "stale-days", 0, "prune branches without commits in this many days (0 to disable)")
+	prune.Flags().BoolVarP(&yes, "yes", "y", false, "delete without asking for confirmation")
+	prune.Flags().BoolVar(&dryRun, "dry-run", false, "only show what would be deleted")
+
+	cmd.AddCommand(prune)
+	return cmd
+}
+
+func runPrune(getConfig func() *config.Config, args []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running branches prune command", "workspace", cfg.WorkspaceRoot)
+
+	if staleDays < 0 {
+		return exitcode.New(exitcode.Usage, fmt.Errorf("--stale-days must not be negative"))
+	}
+
+	out, err := output.NewForData(cfg.Format, "branches")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, args)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	opts := git.PruneOptions{Merged: merged, Gone: gone, StaleDays: staleDays, Now: time.Now()}
+
+	commands := make([]engine.RepoCommand, 0, len(projects))
+	for _, p := range projects {
+		if !p.Exists {
+			continue
+		}
+		repoPath := filepath.Join(cfg.WorkspaceRoot, p.Path)
+		name := p.Path
+		repoOpts := opts
+		repoOpts.Default = p.DefaultBranch
+		commands = append(commands, engine.NewCustomCommand(repoPath, name, func() (string, error) {
+			candidates, err := git.FindPruneCandidates(repoPath, name, repoOpts)
+			if err != nil {
+				return "", err
+			}
+			data, err := json.Marshal(candidates)
+			return string(data), err
+		}))
+	}
+
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: cfg.Parallel})
+
+	var results []export.PruneResult
+	deletable := 0
+	for _, r := range result.Succeeded() {
+		var candidates []git.PruneCandidate
+		if err := json.Unmarshal([]byte(r.Stdout), &candidates); err != nil {
+			return fmt.Errorf("failed to read branches of %s: %w", r.Command.RepoName, err)
+		}
+		for _, c := range candidates {
+			action := export.PruneDelete
+			if c.Keep != "" {
+				action = export.PruneKeep
+			} else {
+				deletable++
+			}
+			results = append(results, export.PruneResult{PruneCandidate: c, Action: action})
+		}
+	}
+
+	if out.IsText() {
+		for _, r := range result.Failed() {
+			out.Warning(fmt.Sprintf("%s: %s", r.Command.RepoName, export.ResultError(r)))
+		}
+		if len(results) == 0 {
+			out.Info("No branches to prune")
+			return exitcode.FromResult(result, "branches")
+		}
+		fmt.Fprintln(output.Stdout(), out.Renderer().RenderColumns([]string{"path", "branch", "reason", "last-commit", "action"}, rows(results, opts.Now)))
+		fmt.Fprintln(output.Stdout())
+	}
+
+	if deletable > 0 && !dryRun {
+		if !yes && !confirm(out, deletable) {
+			out.Info("Aborted, no branches deleted")
+			return nil
+		}
+		deleteBranches(cfg.WorkspaceRoot, results)
+	}
+
+	if !out.IsText() {
+		if err := out.Data("branches", export.NewPruneOutput(results, result.Failed(), dryRun)); err != nil {
+			return err
+		}
+	} else if deletable > 0 && !dryRun {
+		report(out, results)
+	}
+
+	for _, r := range results {
+		if r.Action == export.PruneFailed {
+			return exitcode.New(exitcode.PartialFailure, fmt.Errorf("branches: some branches could not be deleted"))
+		}
+	}
+	return exitcode.FromResult(result, "branches")
+}
+
+func rows(results []export.PruneResult, now time.Time) [][]string {
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		action := r.Action
+		if r.Keep != "" {
+			action = "keep: " + r.Keep
+		}
+		lastCommit := "-"
+		if !r.CommittedAt.IsZero() {
+			lastCommit = export.RelativeTime(r.CommittedAt, now)
+		}
+		rows[i] = []string{r.Repo, r.Branch, strings.Join(r.Reasons, ", "), lastCommit, action}
+	}
+	return rows
+}
+
+func confirm(out *output.Writer, count int) bool {
+	out.Prompt(fmt.Sprintf("Delete %d branch(es)? [y/N]: ", count))
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && input == "" {
+		return false
+	}
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
+
+func deleteBranches(workspaceRoot string, results []export.PruneResult) {
+	for i := range results {
+		r := &results[i]
+		if r.Action != export.PruneDelete {
+			continue
+		}
+		if err := git.DeleteBranch(filepath.Join(workspaceRoot, r.Repo), r.Branch); err != nil {
+			r.Action, r.Error = export.PruneFailed, err.Error()
+			continue
+		}
+		r.Action = export.PruneDeleted
+	}
+}
+
+func report(out *output.Writer, results []export.PruneResult) {
+	deleted := 0
+	for _, r := range results {
+		switch r.Action {
+		case export.PruneDeleted:
+			deleted++
+		case export.PruneFailed:
+			out.Error(fmt.Sprintf("%s: %s", r.Repo, r.Error))
+		}
+	}
+	out.Success(fmt.Sprintf("Deleted %d branch(es)", deleted))
+}
@@ -0,0 +1,35 @@
+package branches
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"gogws/internal/export"
+	"gogws/internal/git"
+)
+
+func TestRows(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	results := []export.PruneResult{
+		{
+			PruneCandidate: git.PruneCandidate{Repo: "api", Branch: "feature", Reasons: []string{git.PruneMerged, git.PruneGone}, CommittedAt: now.AddDate(0, 0, -3)},
+			Action:         export.PruneDelete,
+		},
+		{
+			PruneCandidate: git.PruneCandidate{Repo: "web", Branch: "wip", Reasons: []string{git.PruneStale}, Keep: "unpushed commits"},
+			Action:         export.PruneKeep,
+		},
+	}
+
+	got := rows(results, now)
+	want := [][]string{
+		{"api", "feature", "merged, gone", "3 days ago", "delete"},
+		{"web", "wip", "stale", "-", "keep: unpushed commits"},
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("row %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
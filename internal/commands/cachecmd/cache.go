@@ -0,0 +1,56 @@
+package cachecmd
+
+import (
+	"fmt"
+
+	"gogws/internal/cache"
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/output"
+	"gogws/internal/ui/cli"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the workspace status cache",
+		Long: `Manage the status cache stored in .gws/cache.
+
+'gogws status' reuses cached results for repositories whose index, refs
+and working tree have not changed since the last run.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached data for the workspace",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClear(getConfig)
+		},
+	})
+
+	return cmd
+}
+
+func runClear(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	removed, err := cache.Clear(cfg.WorkspaceRoot)
+	if err != nil {
+		return err
+	}
+
+	renderer := cli.NewRenderer()
+	if !removed {
+		fmt.Fprintln(output.Stdout(), renderer.RenderInfo("Cache is already empty"))
+		return nil
+	}
+
+	fmt.Fprintln(output.Stdout(), renderer.RenderSuccess(fmt.Sprintf("Cleared %s", cache.Dir(cfg.WorkspaceRoot))))
+	return nil
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"slices"
 
+	"gogws/internal/actions"
 	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
 	"gogws/internal/git"
 	"gogws/internal/gws"
 	"gogws/internal/hooks"
-	"gogws/internal/ui/cli"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +22,8 @@ func NewCommand(getConfig func() *config.Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "check",
 		Short: "Check workspace consistency",
-		Long: `Check the workspace for all repositories (known, unknown, ignored, missing).
+		Long: `Check the workspace for all repositories (known, unknown, ignored, missing)
+and for remotes that differ from the projects file.
 This can be slow for large workspaces.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCheck(getConfig)
@@ -29,7 +34,12 @@ This can be slow for large workspaces.`,
 func runCheck(getConfig func() *config.Config) error {
 	cfg := getConfig()
 	if cfg == nil {
-		return fmt.Errorf("no workspace found (no .projects.gws file)")
+		return exitcode.ErrNoWorkspace
+	}
+
+	out, err := output.NewForData(cfg.Format, "check")
+	if err != nil {
+		return err
 	}
 
 	if err := hooks.PreCheck(cfg.WorkspaceRoot); err != nil {
@@ -44,28 +54,57 @@ func runCheck(getConfig func() *config.Config) error {
 	}
 	slog.Debug("Loaded projects", "projects", ws.Projects)
 
-	renderer := cli.NewRenderer()
+	out.Info("Checking known repositories...")
 
-	fmt.Println(renderer.RenderInfo("Checking known repositories..."))
-
-	missing := 0
+	var present, missing []string
 	for _, project := range ws.Projects {
 		repoPath := filepath.Join(cfg.WorkspaceRoot, project.Path)
 		status := git.GetStatus(repoPath)
 		if !status.Exists {
-			fmt.Println(renderer.RenderError(fmt.Sprintf("Missing: %s", project.Path)))
-			missing++
+			out.Error(fmt.Sprintf("Missing: %s", project.Path))
+			missing = append(missing, project.Path)
+			continue
+		}
+		present = append(present, project.Path)
+	}
+
+	if len(missing) == 0 {
+		out.Success("All known repositories are present")
+	} else {
+		out.Warning(fmt.Sprintf("%d repositories are missing", len(missing)))
+	}
+
+	out.Text("")
+	out.Info("Comparing remotes with the projects file...")
+
+	drift := make(map[string][]git.RemoteChange)
+	for _, project := range ws.Projects {
+		if !slices.Contains(present, project.Path) {
+			continue
+		}
+		changes, _, err := actions.RemoteDrift(cfg.WorkspaceRoot, project, false)
+		if err != nil {
+			out.Warning(fmt.Sprintf("%s: %v", project.Path, err))
+			continue
+		}
+		if len(changes) > 0 {
+			drift[project.Path] = changes
 		}
 	}
 
-	if missing == 0 {
-		fmt.Println(renderer.RenderSuccess("All known repositories are present"))
+	if len(drift) == 0 {
+		out.Success("All remotes match the projects file")
 	} else {
-		fmt.Println(renderer.RenderWarning(fmt.Sprintf("%d repositories are missing", missing)))
+		out.Warning(fmt.Sprintf("%d repositories have drifted remotes (fix with 'gogws remotes sync'):", len(drift)))
+		for _, path := range present {
+			for _, change := range drift[path] {
+				out.Info(fmt.Sprintf("  %s: %s", path, change))
+			}
+		}
 	}
 
-	fmt.Println()
-	fmt.Println(renderer.RenderInfo("Scanning for unknown repositories..."))
+	out.Text("")
+	out.Info("Scanning for unknown repositories...")
 
 	knownPaths := make([]string, len(ws.Projects))
 	for i, p := range ws.Projects {
@@ -78,11 +117,17 @@ func runCheck(getConfig func() *config.Config) error {
 	}
 
 	if len(unknown) == 0 {
-		fmt.Println(renderer.RenderSuccess("No unknown repositories found"))
+		out.Success("No unknown repositories found")
 	} else {
-		fmt.Println(renderer.RenderWarning(fmt.Sprintf("Found %d unknown repositories:", len(unknown))))
+		out.Warning(fmt.Sprintf("Found %d unknown repositories:", len(unknown)))
 		for _, path := range unknown {
-			fmt.Println(renderer.RenderInfo(fmt.Sprintf("  %s", path)))
+			out.Info(fmt.Sprintf("  %s", path))
+		}
+	}
+
+	if !out.IsText() {
+		if err := out.Data("check", export.NewCheckOutput(present, missing, unknown, drift)); err != nil {
+			return err
 		}
 	}
 
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"time"
 
 	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
 	"gogws/internal/git"
 	"gogws/internal/gws"
 	"gogws/internal/hooks"
-	"gogws/internal/ui/cli"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -29,7 +32,7 @@ func NewCommand(getConfig func() *config.Config) *cobra.Command {
 func runClone(getConfig func() *config.Config, args []string) error {
 	cfg := getConfig()
 	if cfg == nil {
-		return fmt.Errorf("no workspace found (no .projects.gws file)")
+		return exitcode.ErrNoWorkspace
 	}
 
 	slog.Debug("Running clone command", "workspace", cfg.WorkspaceRoot)
@@ -44,45 +47,78 @@ func runClone(getConfig func() *config.Config, args []string) error {
 		projectMap[project.Path] = project
 	}
 
-	renderer := cli.NewRenderer()
+	out, err := output.New(cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	result := engine.NewExecuteResult()
+	startTime := time.Now()
 
 	for _, repoPath := range args {
+		fullPath := filepath.Join(cfg.WorkspaceRoot, repoPath)
+		cmd := engine.NewGitCommand(fullPath, repoPath, "clone")
+
 		project, exists := projectMap[repoPath]
 		if !exists {
-			fmt.Println(renderer.RenderError(fmt.Sprintf("%s: not found in .projects.gws", repoPath)))
+			out.Error(fmt.Sprintf("%s: not found in .projects.gws", repoPath))
+			result.AddResult(engine.Result{Command: cmd, Error: fmt.Errorf("not found in .projects.gws")})
 			continue
 		}
 
-		fullPath := filepath.Join(cfg.WorkspaceRoot, project.Path)
 		status := git.GetStatus(fullPath)
 		if status.Exists {
-			fmt.Println(renderer.RenderWarning(fmt.Sprintf("%s: already exists", repoPath)))
+			out.Warning(fmt.Sprintf("%s: already exists", repoPath))
+			result.AddResult(engine.Skip(cmd, "already exists"))
 			continue
 		}
 
-		if err := hooks.PreClone(cfg.WorkspaceRoot, repoPath); err != nil {
-			fmt.Println(renderer.RenderError(fmt.Sprintf("%s: pre-clone hook failed: %v", repoPath, err)))
+		urls := make([]string, len(project.Remotes))
+		for i, r := range project.Remotes {
+			urls[i] = r.URL
+		}
+
+		resp, err := hooks.PreClone(cfg.WorkspaceRoot, repoPath, urls)
+		if err != nil {
+			out.Error(fmt.Sprintf("%s: pre-clone hook failed: %v", repoPath, err))
+			result.AddResult(engine.Result{Command: cmd, Error: fmt.Errorf("pre-clone hook failed: %w", err)})
+			continue
+		}
+		if reason, ok := resp.SkipReason(repoPath); ok {
+			reason = hooks.SkipMessage(hooks.HookPreClone, reason)
+			out.Warning(fmt.Sprintf("%s: skipped (%s)", repoPath, reason))
+			result.AddResult(engine.Skip(cmd, reason))
 			continue
 		}
 
 		slog.Debug("Cloning", "path", repoPath)
-		fmt.Println(renderer.RenderInfo(fmt.Sprintf("Cloning %s...", repoPath)))
+		out.Info(fmt.Sprintf("Cloning %s...", repoPath))
 
 		remotes := toGitRemotes(project.Remotes)
-		err := git.CloneWorkspace(cfg.WorkspaceRoot, project.Path, remotes)
+		cloneStart := time.Now()
+		err = git.CloneWorkspace(cfg.WorkspaceRoot, project.Path, remotes)
 		success := err == nil
+		result.AddResult(engine.Result{Command: cmd, Success: success, Error: err, Duration: time.Since(cloneStart)})
 		if err != nil {
-			fmt.Println(renderer.RenderError(fmt.Sprintf("%s: %v", repoPath, err)))
+			out.Error(fmt.Sprintf("%s: %v", repoPath, err))
 		} else {
-			fmt.Println(renderer.RenderSuccess(repoPath))
+			out.Success(repoPath)
 		}
 
 		if hookErr := hooks.PostClone(cfg.WorkspaceRoot, repoPath, success); hookErr != nil {
-			fmt.Println(renderer.RenderWarning(fmt.Sprintf("%s: post-clone hook failed: %v", repoPath, hookErr)))
+			out.Warning(fmt.Sprintf("%s: post-clone hook failed: %v", repoPath, hookErr))
+		}
+	}
+
+	result.TotalDuration = time.Since(startTime)
+
+	if !out.IsText() {
+		if err := out.Results(result, "clone", "Cloned"); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return exitcode.FromResult(result, "clone")
 }
 
 func toGitRemotes(remotes []gws.Remote) []git.Remote {
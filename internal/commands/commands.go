@@ -2,17 +2,35 @@ package commands
 
 import (
 	"context"
+	"gogws/internal/commands/alias"
+	"gogws/internal/commands/apply"
+	"gogws/internal/commands/branches"
+	"gogws/internal/commands/cachecmd"
 	"gogws/internal/commands/check"
 	"gogws/internal/commands/clone"
+	"gogws/internal/commands/commit"
 	"gogws/internal/commands/configcmd"
 	"gogws/internal/commands/dev"
+	"gogws/internal/commands/diff"
 	"gogws/internal/commands/fetch"
 	"gogws/internal/commands/ff"
+	"gogws/internal/commands/grep"
+	"gogws/internal/commands/incoming"
 	"gogws/internal/commands/initcmd"
+	"gogws/internal/commands/logcmd"
+	"gogws/internal/commands/manifest"
+	"gogws/internal/commands/remotes"
 	"gogws/internal/commands/root"
+	"gogws/internal/commands/schema"
+	"gogws/internal/commands/serve"
 	"gogws/internal/commands/status"
+	"gogws/internal/commands/uicmd"
 	"gogws/internal/commands/update"
 	"gogws/internal/commands/version"
+	"gogws/internal/commands/watch"
+	"gogws/internal/exitcode"
+	"io"
+	"os"
 
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
@@ -27,16 +45,39 @@ func Execute() error {
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(clone.NewCommand(root.GetConfig))
 	rootCmd.AddCommand(fetch.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(incoming.NewCommand(root.GetConfig))
 	rootCmd.AddCommand(ff.NewCommand(root.GetConfig))
 	rootCmd.AddCommand(check.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(logcmd.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(commit.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(apply.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(branches.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(remotes.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(manifest.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(diff.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(grep.NewCommand(root.GetConfig))
 	rootCmd.AddCommand(initcmd.NewCommand(root.GetConfig))
 	rootCmd.AddCommand(update.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(uicmd.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(watch.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(serve.NewCommand(root.GetConfig))
+	rootCmd.AddCommand(cachecmd.NewCommand(root.GetConfig))
 	rootCmd.AddCommand(configcmd.NewCommand())
+	rootCmd.AddCommand(schema.NewCommand())
 	rootCmd.AddCommand(dev.NewCommand())
 
+	alias.Register(rootCmd, os.Args[1:])
+
 	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		return statusCmd.RunE(cmd, args)
 	}
 
-	return fang.Execute(context.Background(), rootCmd)
+	return fang.Execute(context.Background(), rootCmd, fang.WithErrorHandler(errorHandler))
+}
+
+func errorHandler(w io.Writer, styles fang.Styles, err error) {
+	if exitcode.IsSilent(err) {
+		return
+	}
+	fang.DefaultErrorHandler(w, styles, err)
 }
@@ -0,0 +1,141 @@
+package commit
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	message  string
+	all      bool
+	trailer  string
+	changeID string
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit -m <message> [project...]",
+		Short: "Commit in every repository with the same message",
+		Long: `Commit staged changes in every repository (or the given projects) with
+the same message. Each commit gets a shared trailer such as
+
+    Workspace-Change: 3f0c9a52-5b1e-4f7e-9d8a-2c4b6e1f0a7d
+
+so the related commits can be found later with "gogws log --change <id>".
+A new identifier is generated unless --change is given.
+
+With --all, modified tracked files are committed too, like git commit -a.
+Repositories with nothing to commit are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommit(getConfig, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "commit message")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "also commit modified tracked files that are not staged")
+	cmd.Flags().StringVar(&trailer, "trailer", git.DefaultChangeTrailer, "name of the trailer carrying the change identifier")
+	cmd.Flags().StringVar(&changeID, "change", "", "reuse an existing change identifier instead of generating one")
+	_ = cmd.MarkFlagRequired("message")
+
+	return cmd
+}
+
+func runCommit(getConfig func() *config.Config, args []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running commit command", "workspace", cfg.WorkspaceRoot)
+
+	if message == "" {
+		return exitcode.New(exitcode.Usage, fmt.Errorf("commit message must not be empty"))
+	}
+	if trailer == "" {
+		return exitcode.New(exitcode.Usage, fmt.Errorf("trailer name must not be empty"))
+	}
+
+	out, err := output.New(cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, args)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	id := changeID
+	if id == "" {
+		id = newChangeID()
+	}
+
+	commands := make([]engine.RepoCommand, 0, len(projects))
+	var skippedResults []engine.Result
+
+	for _, p := range projects {
+		repoPath := filepath.Join(cfg.WorkspaceRoot, p.Path)
+		cmd := engine.NewGitCommand(repoPath, p.Path, git.CommitArgs(message, trailer, id, all)...)
+		cmd = cmd.WithContext(export.ChangeContextKey, id)
+
+		if !p.Exists {
+			skippedResults = append(skippedResults, engine.Skip(cmd, "not cloned yet"))
+			continue
+		}
+
+		changed, err := git.HasChangesToCommit(repoPath, all)
+		if err != nil {
+			skippedResults = append(skippedResults, engine.Result{Command: cmd, Error: err})
+			continue
+		}
+		if !changed {
+			skippedResults = append(skippedResults, engine.Skip(cmd, "nothing to commit"))
+			continue
+		}
+
+		commands = append(commands, cmd)
+	}
+
+	stopProgress := out.Progress()
+	result := engine.Execute(commands, engine.ExecuteOptions{
+		Parallel:    cfg.Parallel,
+		StopOnError: cfg.StopOnError,
+		Settled:     skippedResults,
+	})
+	stopProgress()
+
+	if err := out.Results(result, "commit", "Committed"); err != nil {
+		return err
+	}
+
+	if result.SuccessCount() > 0 {
+		out.Info(fmt.Sprintf("%s: %s", trailer, id))
+	}
+
+	return exitcode.FromResult(result, "commit")
+}
+
+func newChangeID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,18 @@
+package commit
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewChangeID(t *testing.T) {
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	a, b := newChangeID(), newChangeID()
+	if !uuidV4.MatchString(a) {
+		t.Errorf("not a version 4 UUID: %s", a)
+	}
+	if a == b {
+		t.Errorf("expected unique identifiers, got %s twice", a)
+	}
+}
@@ -3,20 +3,30 @@ package configcmd
 import (
 	"fmt"
 	"log/slog"
-	"strings"
+	"sort"
 
 	"gogws/internal/config"
+	"gogws/internal/output"
 	"gogws/internal/ui/cli"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	setWorkspace bool
+	showOrigin   bool
+)
+
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage gogws configuration",
-		Long:  `View and manage gogws user configuration stored in ~/.gws/config.yaml`,
-		RunE:  runConfigShow,
+		Long: `View and manage gogws configuration.
+
+Values are resolved from these layers, later layers winning:
+  defaults, user file (~/.gws/config.yaml), workspace file (.gws/config.yaml),
+  environment (GOGWS_*), command-line flags`,
+		RunE: runConfigShow,
 	}
 
 	cmd.AddCommand(newGetCommand())
@@ -30,82 +40,131 @@ func newGetCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "get <key>",
 		Short: "Get a configuration value",
-		Long:  `Get a specific configuration value by key.`,
+		Long:  `Get the effective value of a configuration key and where it came from.`,
 		Args:  cobra.ExactArgs(1),
 		RunE:  runConfigGet,
 	}
 }
 
 func newSetCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
-		Long: `Set a configuration value.
+		Long: `Set a configuration value in the user configuration (~/.gws/config.yaml),
+or in the workspace configuration (.gws/config.yaml) with --workspace.
 
-Available keys:
-  trusted-workspaces    List of trusted workspace paths for hooks`,
+Run 'gogws config list' to see the available keys.`,
 		Args: cobra.ExactArgs(2),
 		RunE: runConfigSet,
 	}
+
+	cmd.Flags().BoolVar(&setWorkspace, "workspace", false, "write to the workspace configuration (.gws/config.yaml)")
+
+	return cmd
 }
 
 func newListCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all available configuration keys",
 		RunE:  runConfigList,
 	}
+
+	cmd.Flags().BoolVar(&showOrigin, "show-origin", false, "show the effective value of each key and where it came from")
+
+	return cmd
 }
 
 func runConfigShow(cmd *cobra.Command, args []string) error {
-	slog.Debug("Loading user configuration...")
+	slog.Debug("Loading configuration...")
 
-	resolved, err := config.LoadUserConfigResolved()
-	if err != nil {
-		return fmt.Errorf("failed to load user config: %w", err)
+	resolved := config.GetResolved()
+	if resolved == nil {
+		return fmt.Errorf("configuration not initialized")
 	}
 
-	configPath, _ := config.GetUserConfigPath()
+	userPath, _ := config.GetUserConfigPath()
 
 	renderer := cli.NewRenderer()
 
-	fmt.Println(renderer.RenderHeader("GOGWS Configuration"))
-	fmt.Println()
-	fmt.Printf("  File: %s\n\n", configPath)
+	fmt.Fprintln(output.Stdout(), renderer.RenderHeader("GOGWS Configuration"))
+	fmt.Fprintln(output.Stdout())
+	fmt.Fprintf(output.Stdout(), "  User file:      %s\n", userPath)
+	if cfg := config.GetConfig(); cfg != nil {
+		fmt.Fprintf(output.Stdout(), "  Workspace file: %s\n", config.GetWorkspaceConfigPath(cfg.WorkspaceRoot))
+	}
+	fmt.Fprintln(output.Stdout())
 
-	if len(resolved.TrustedWorkspaces.Value) > 0 {
-		fmt.Println(renderer.RenderConfigValue("trusted-workspaces", resolved.TrustedWorkspaces.Value, string(resolved.TrustedWorkspaces.Source)))
-	} else {
-		fmt.Println(renderer.RenderConfigValue("trusted-workspaces", "(none)", string(resolved.TrustedWorkspaces.Source)))
+	for _, key := range config.GetAvailableConfigKeys() {
+		value, origin, err := resolved.Lookup(key)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(output.Stdout(), renderer.RenderConfigValue(key, displayValue(value), origin))
+	}
+
+	for _, name := range aliasNames(resolved) {
+		alias := resolved.Aliases[name]
+		fmt.Fprintln(output.Stdout(), renderer.RenderConfigValue(config.AliasKeyPrefix+name, alias.Value.String(), alias.Origin()))
+	}
+
+	for _, name := range varNames(resolved) {
+		v, _ := resolved.LookupVar(name)
+		fmt.Fprintln(output.Stdout(), renderer.RenderConfigValue(config.VarKeyPrefix+name, displayValue(v.Value), v.Origin()))
+	}
+
+	for _, rw := range resolved.URLRewrites {
+		fmt.Fprintln(output.Stdout(), renderer.RenderConfigValue(rw.Key(), rw.InsteadOf, rw.Origin()))
 	}
 
 	return nil
 }
 
+func aliasNames(resolved *config.Resolved) []string {
+	names := make([]string, 0, len(resolved.Aliases))
+	for name := range resolved.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func varNames(resolved *config.Resolved) []string {
+	names := make([]string, 0, len(resolved.Vars))
+	for name := range resolved.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func runConfigGet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	slog.Debug("Getting config value", "key", key)
 
-	resolved, err := config.LoadUserConfigResolved()
+	resolved := config.GetResolved()
+	if resolved == nil {
+		return fmt.Errorf("configuration not initialized")
+	}
+
+	value, origin, err := resolved.Lookup(key)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
-	switch key {
-	case "trusted-workspaces":
-		if len(resolved.TrustedWorkspaces.Value) == 0 {
-			fmt.Printf("(none) (source: %s)\n", resolved.TrustedWorkspaces.Source)
-		} else {
-			fmt.Printf("(source: %s)\n", resolved.TrustedWorkspaces.Source)
-			for _, ws := range resolved.TrustedWorkspaces.Value {
-				fmt.Printf("  - %s\n", ws)
-			}
+	if list, ok := value.([]string); ok {
+		if len(list) == 0 {
+			fmt.Fprintf(output.Stdout(), "(none) (source: %s)\n", origin)
+			return nil
 		}
-	default:
-		return fmt.Errorf("unknown configuration key: %s\n\nAvailable keys:\n  %s",
-			key, strings.Join(config.GetAvailableConfigKeys(), "\n  "))
+		fmt.Fprintf(output.Stdout(), "(source: %s)\n", origin)
+		for _, item := range list {
+			fmt.Fprintf(output.Stdout(), "  - %s\n", item)
+		}
+		return nil
 	}
 
+	fmt.Fprintf(output.Stdout(), "%s (source: %s)\n", displayValue(value), origin)
 	return nil
 }
 
@@ -113,39 +172,102 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	valueStr := args[1]
 
-	slog.Debug("Setting config value", "key", key, "value", valueStr)
+	slog.Debug("Setting config value", "key", key, "value", valueStr, "workspace", setWorkspace)
 
-	switch key {
-	case "trusted-workspaces":
-		if err := config.AddTrustedWorkspace(valueStr); err != nil {
-			return fmt.Errorf("failed to add trusted workspace: %w", err)
+	scope := config.ScopeUser
+	workspaceRoot := ""
+	if setWorkspace {
+		scope = config.ScopeWorkspace
+		if cfg := config.GetConfig(); cfg != nil {
+			workspaceRoot = cfg.WorkspaceRoot
 		}
-		renderer := cli.NewRenderer()
-		fmt.Println(renderer.RenderSuccess(fmt.Sprintf("Added trusted workspace: %s", valueStr)))
-		return nil
-	default:
-		return fmt.Errorf("unknown configuration key: %s\n\nAvailable keys:\n  %s",
-			key, strings.Join(config.GetAvailableConfigKeys(), "\n  "))
 	}
+
+	if err := config.SetValue(scope, workspaceRoot, key, valueStr); err != nil {
+		return err
+	}
+
+	renderer := cli.NewRenderer()
+	if key == "trusted-workspaces" {
+		fmt.Fprintln(output.Stdout(), renderer.RenderSuccess(fmt.Sprintf("Added trusted workspace: %s", valueStr)))
+	} else {
+		fmt.Fprintln(output.Stdout(), renderer.RenderSuccess(fmt.Sprintf("Set %s = %s (%s)", key, valueStr, scope)))
+	}
+	return nil
 }
 
 func runConfigList(cmd *cobra.Command, args []string) error {
 	renderer := cli.NewRenderer()
 
-	fmt.Println(renderer.RenderHeader("Available Configuration Keys"))
-	fmt.Println()
+	if showOrigin {
+		resolved := config.GetResolved()
+		if resolved == nil {
+			return fmt.Errorf("configuration not initialized")
+		}
+		for _, key := range config.GetAvailableConfigKeys() {
+			value, origin, err := resolved.Lookup(key)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(output.Stdout(), "%-60s %s=%s\n", origin, key, displayValue(value))
+		}
+		for _, name := range aliasNames(resolved) {
+			alias := resolved.Aliases[name]
+			fmt.Fprintf(output.Stdout(), "%-60s %s%s=%s\n", alias.Origin(), config.AliasKeyPrefix, name, alias.Value.String())
+		}
+		for _, name := range varNames(resolved) {
+			v, _ := resolved.LookupVar(name)
+			fmt.Fprintf(output.Stdout(), "%-60s %s%s=%s\n", v.Origin(), config.VarKeyPrefix, name, v.Value)
+		}
+		for _, rw := range resolved.URLRewrites {
+			fmt.Fprintf(output.Stdout(), "%-60s %s=%s\n", rw.Origin(), rw.Key(), rw.InsteadOf)
+		}
+		return nil
+	}
 
-	keys := config.GetAvailableConfigKeys()
-	for _, key := range keys {
-		fmt.Printf("  %s\n", key)
+	fmt.Fprintln(output.Stdout(), renderer.RenderHeader("Available Configuration Keys"))
+	fmt.Fprintln(output.Stdout())
 
-		switch key {
-		case "trusted-workspaces":
-			fmt.Printf("    type: list of paths\n")
-			fmt.Printf("    desc: Workspace paths where local hooks are trusted\n")
+	for _, key := range config.GetAvailableKeys() {
+		fmt.Fprintf(output.Stdout(), "  %s\n", key.Name)
+		fmt.Fprintf(output.Stdout(), "    type: %s\n", key.Type)
+		fmt.Fprintf(output.Stdout(), "    desc: %s\n", key.Description)
+		if envVar := config.GetEnvVarName(key.Name); envVar != "" {
+			fmt.Fprintf(output.Stdout(), "    env:  %s\n", envVar)
 		}
-		fmt.Println()
+		if key.UserOnly {
+			fmt.Fprintf(output.Stdout(), "    scope: user only\n")
+		}
+		fmt.Fprintln(output.Stdout())
 	}
 
+	fmt.Fprintf(output.Stdout(), "  %s<name>\n", config.AliasKeyPrefix)
+	fmt.Fprintf(output.Stdout(), "    type: command or list of commands\n")
+	fmt.Fprintf(output.Stdout(), "    desc: Command alias, e.g. 'gogws config set alias.wip \"status --only-changes\"'\n")
+	fmt.Fprintln(output.Stdout())
+
+	fmt.Fprintf(output.Stdout(), "  %s<NAME>\n", config.VarKeyPrefix)
+	fmt.Fprintf(output.Stdout(), "    type: string\n")
+	fmt.Fprintf(output.Stdout(), "    desc: Variable for ${NAME} in manifest URLs, e.g. 'gogws config set var.GIT_BASE git@github.com:company'\n")
+	fmt.Fprintf(output.Stdout(), "    env:  NAME\n")
+	fmt.Fprintln(output.Stdout())
+
 	return nil
 }
+
+func displayValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return "(none)"
+		}
+		return v
+	case []string:
+		if len(v) == 0 {
+			return "(none)"
+		}
+		return fmt.Sprintf("%v", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"gogws/internal/gitignore"
 	"gogws/internal/gws"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -79,14 +80,14 @@ func runGenerate() error {
 		return fmt.Errorf("failed to resolve output directory: %w", err)
 	}
 
-	fmt.Printf("Generating test workspace structure in: %s\n", absOutput)
-	fmt.Printf("  Projects per workspace: %d\n", numProjects)
-	fmt.Printf("  Workspaces per level: %d\n", numWorkspaces)
-	fmt.Printf("  Max depth: %d\n", maxDepth)
-	fmt.Printf("  Base URL: %s\n", baseURL)
-	fmt.Printf("  Init repos: %v\n", initRepos)
-	fmt.Printf("  Generate .gitignore: %v\n", generateGitIgnore)
-	fmt.Println()
+	fmt.Fprintf(output.Stdout(), "Generating test workspace structure in: %s\n", absOutput)
+	fmt.Fprintf(output.Stdout(), "  Projects per workspace: %d\n", numProjects)
+	fmt.Fprintf(output.Stdout(), "  Workspaces per level: %d\n", numWorkspaces)
+	fmt.Fprintf(output.Stdout(), "  Max depth: %d\n", maxDepth)
+	fmt.Fprintf(output.Stdout(), "  Base URL: %s\n", baseURL)
+	fmt.Fprintf(output.Stdout(), "  Init repos: %v\n", initRepos)
+	fmt.Fprintf(output.Stdout(), "  Generate .gitignore: %v\n", generateGitIgnore)
+	fmt.Fprintln(output.Stdout())
 
 	wsInfo := generateWorkspace(absOutput, prefix, "", 0)
 
@@ -96,8 +97,8 @@ func runGenerate() error {
 		}
 	}
 
-	fmt.Println()
-	fmt.Printf("Generation complete!\n")
+	fmt.Fprintln(output.Stdout())
+	fmt.Fprintf(output.Stdout(), "Generation complete!\n")
 	printStats(wsInfo)
 
 	return nil
@@ -106,13 +107,13 @@ func runGenerate() error {
 func generateWorkspace(dir, pfx, parentPath string, depth int) workspaceInfo {
 	gwsDir := filepath.Join(dir, gws.ConfigDirName)
 	if err := os.MkdirAll(gwsDir, 0755); err != nil {
-		fmt.Printf("Error creating %s directory: %v\n", gws.ConfigDirName, err)
+		fmt.Fprintf(output.Stdout(), "Error creating %s directory: %v\n", gws.ConfigDirName, err)
 		return workspaceInfo{}
 	}
 
 	if generateGitIgnore {
 		if err := gitignore.CreateGitignore(dir); err != nil {
-			fmt.Printf("Error creating .gitignore: %v\n", err)
+			fmt.Fprintf(output.Stdout(), "Error creating .gitignore: %v\n", err)
 		}
 	}
 
@@ -141,15 +142,15 @@ func generateWorkspace(dir, pfx, parentPath string, depth int) workspaceInfo {
 		if initRepos {
 			projDir := filepath.Join(dir, name)
 			if err := createProjectRepo(projDir, proj); err != nil {
-				fmt.Printf("Error creating project repo %s: %v\n", name, err)
+				fmt.Fprintf(output.Stdout(), "Error creating project repo %s: %v\n", name, err)
 			} else {
-				fmt.Printf("  Created project: %s\n", name)
+				fmt.Fprintf(output.Stdout(), "  Created project: %s\n", name)
 			}
 		}
 	}
 
 	if err := writeProjectsFile(gwsDir, wsInfo.Projects); err != nil {
-		fmt.Printf("Error writing projects.gws: %v\n", err)
+		fmt.Fprintf(output.Stdout(), "Error writing projects.gws: %v\n", err)
 	}
 
 	if depth < maxDepth {
@@ -166,7 +167,7 @@ func generateWorkspace(dir, pfx, parentPath string, depth int) workspaceInfo {
 
 			childDir := filepath.Join(dir, name)
 			if err := os.MkdirAll(childDir, 0755); err != nil {
-				fmt.Printf("Error creating workspace directory %s: %v\n", name, err)
+				fmt.Fprintf(output.Stdout(), "Error creating workspace directory %s: %v\n", name, err)
 				continue
 			}
 
@@ -178,15 +179,15 @@ func generateWorkspace(dir, pfx, parentPath string, depth int) workspaceInfo {
 
 			if initRepos {
 				if err := createWorkspaceRepo(childDir, childWs); err != nil {
-					fmt.Printf("Error creating workspace repo %s: %v\n", name, err)
+					fmt.Fprintf(output.Stdout(), "Error creating workspace repo %s: %v\n", name, err)
 				} else {
-					fmt.Printf("  Created workspace: %s\n", name)
+					fmt.Fprintf(output.Stdout(), "  Created workspace: %s\n", name)
 				}
 			}
 		}
 
 		if err := writeWorkspacesFile(gwsDir, wsInfo.Workspaces); err != nil {
-			fmt.Printf("Error writing workspaces.gws: %v\n", err)
+			fmt.Fprintf(output.Stdout(), "Error writing workspaces.gws: %v\n", err)
 		}
 	}
 
@@ -421,9 +422,9 @@ func printStats(ws workspaceInfo) {
 	totalProjects := countProjects(ws)
 	totalWorkspaces := countWorkspaces(ws)
 
-	fmt.Printf("\nStatistics:\n")
-	fmt.Printf("  Total projects: %d\n", totalProjects)
-	fmt.Printf("  Total workspaces: %d\n", totalWorkspaces)
+	fmt.Fprintf(output.Stdout(), "\nStatistics:\n")
+	fmt.Fprintf(output.Stdout(), "  Total projects: %d\n", totalProjects)
+	fmt.Fprintf(output.Stdout(), "  Total workspaces: %d\n", totalWorkspaces)
 }
 
 func countProjects(ws workspaceInfo) int {
@@ -0,0 +1,135 @@
+package diff
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"path/filepath"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	stat     bool
+	nameOnly bool
+	staged   bool
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [project...]",
+		Short: "Show uncommitted changes across all repositories",
+		Long: `Run git diff in every repository (or the given projects) in parallel and
+combine the output. File paths are prefixed with the project path, so
+"a/api/src/client.go" refers to src/client.go in the api project.
+
+Like git diff, unstaged changes are shown by default; use --staged for
+changes in the index. Untracked files are not included.
+
+Structured output (--format json) lists changed files with their line
+counts.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(getConfig, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&stat, "stat", false, "show a diffstat instead of the patch")
+	cmd.Flags().BoolVar(&nameOnly, "name-only", false, "show only the names of changed files")
+	cmd.Flags().BoolVar(&staged, "staged", false, "show staged changes instead of unstaged ones")
+	cmd.MarkFlagsMutuallyExclusive("stat", "name-only")
+
+	return cmd
+}
+
+func runDiff(getConfig func() *config.Config, args []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running diff command", "workspace", cfg.WorkspaceRoot)
+
+	out, err := output.NewForData(cfg.Format, "diff")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, args)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	patch := out.IsText() && !stat && !nameOnly
+
+	commands := make([]engine.RepoCommand, 0, len(projects))
+	for _, p := range projects {
+		if !p.Exists {
+			continue
+		}
+		gitArgs := git.DiffNumstatArgs(staged)
+		if patch {
+			gitArgs = git.DiffArgs(p.Path, staged)
+		}
+		commands = append(commands, engine.NewGitCommand(filepath.Join(cfg.WorkspaceRoot, p.Path), p.Path, gitArgs...))
+	}
+
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: cfg.Parallel})
+
+	if out.IsText() {
+		for _, r := range result.Failed() {
+			out.Warning(fmt.Sprintf("%s: %s", r.Command.RepoName, export.ResultError(r)))
+		}
+	}
+
+	if patch {
+		empty := true
+		for _, r := range result.Succeeded() {
+			if r.Stdout != "" {
+				fmt.Fprint(output.Stdout(), r.Stdout)
+				empty = false
+			}
+		}
+		if empty {
+			out.Info("No changes")
+		}
+		return exitcode.FromResult(result, "diff")
+	}
+
+	var files []git.DiffFile
+	for _, r := range result.Succeeded() {
+		files = append(files, git.ParseNumstat(r.Command.RepoName, r.Stdout)...)
+	}
+
+	if !out.IsText() {
+		if err := out.Data("diff", export.NewDiffOutput(files, result.Failed(), staged)); err != nil {
+			return err
+		}
+		return exitcode.FromResult(result, "diff")
+	}
+
+	switch {
+	case len(files) == 0:
+		out.Info("No changes")
+	case nameOnly:
+		for _, f := range files {
+			fmt.Fprintln(output.Stdout(), path.Join(f.Repo, f.Path))
+		}
+	default:
+		fmt.Fprint(output.Stdout(), out.Renderer().RenderDiffStat(files))
+	}
+
+	return exitcode.FromResult(result, "diff")
+}
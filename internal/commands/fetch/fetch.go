@@ -3,37 +3,45 @@ package fetch
 import (
 	"fmt"
 	"log/slog"
-	"path/filepath"
 
+	"gogws/internal/actions"
 	"gogws/internal/config"
-	"gogws/internal/engine"
-	"gogws/internal/git"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
 	"gogws/internal/gws"
 	"gogws/internal/hooks"
-	"gogws/internal/ui/cli"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
 
+var report bool
+
 func NewCommand(getConfig func() *config.Config) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "fetch",
 		Short: "Fetch updates from origin for all repositories",
-		Long:  `Fetch updates from origin remote for all repositories in the workspace.`,
+		Long: `Fetch updates from origin remote for all repositories in the workspace.
+
+Every fetch records which remote-tracking branches and tags changed: new
+commits per branch, force-updated (rewritten) branches, new tags and deleted
+remote branches. Use --report to print it right away, or "gogws incoming" to
+show the report of the last fetch later. Structured output includes the
+changes under each result's "incoming" field.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runFetch(getConfig)
 		},
 	}
+
+	cmd.Flags().BoolVar(&report, "report", false, "show what changed upstream after fetching")
+
+	return cmd
 }
 
 func runFetch(getConfig func() *config.Config) error {
 	cfg := getConfig()
 	if cfg == nil {
-		return fmt.Errorf("no workspace found (no .projects.gws file)")
-	}
-
-	if err := hooks.PreFetch(cfg.WorkspaceRoot); err != nil {
-		return fmt.Errorf("pre-fetch hook failed: %w", err)
+		return exitcode.ErrNoWorkspace
 	}
 
 	slog.Debug("Running fetch command", "workspace", cfg.WorkspaceRoot)
@@ -43,39 +51,42 @@ func runFetch(getConfig func() *config.Config) error {
 		return fmt.Errorf("failed to load projects: %w", err)
 	}
 
-	renderer := cli.NewRenderer()
-	output := engine.NewOutputHandler(renderer, false)
-
-	commands := make([]engine.RepoCommand, 0, len(ws.Projects))
-	var skippedResults []engine.Result
-
-	for _, p := range ws.Projects {
-		repoPath := filepath.Join(cfg.WorkspaceRoot, p.Path)
-		status := git.GetStatus(repoPath)
-
-		if !status.Exists {
-			cmd := engine.NewGitCommand(repoPath, p.Path, "fetch", "--all")
-			skippedResults = append(skippedResults, engine.Skip(cmd, "not cloned yet"))
-			continue
-		}
-
-		commands = append(commands, engine.NewGitCommand(repoPath, p.Path, "fetch", "--all"))
+	out, err := output.New(cfg.Format)
+	if err != nil {
+		return err
 	}
 
-	result := engine.Execute(commands, engine.ExecuteOptions{
+	result, changes, err := actions.Fetch(cfg.WorkspaceRoot, ws.Projects, actions.Options{
 		Parallel:    cfg.Parallel,
 		StopOnError: cfg.StopOnError,
+		Progress:    out.Progress,
 	})
+	if err != nil {
+		return err
+	}
 
-	for _, r := range skippedResults {
-		result.AddResult(r)
+	results := export.NewResultsOutput(result, "fetch")
+	results.SetIncoming(changes)
+	if err := out.ResultsOutput(result, results, "Fetched"); err != nil {
+		return err
 	}
 
-	output.RenderSummary(result, "Fetched")
+	incoming, err := actions.RecordIncoming(cfg.WorkspaceRoot, changes)
+	if err != nil {
+		out.Warning(err.Error())
+	}
+
+	if report && out.IsText() {
+		if len(incoming.Repositories) == 0 {
+			out.Info("No incoming changes")
+		} else {
+			fmt.Fprint(output.Stdout(), "\n"+out.Renderer().RenderIncoming(incoming.Repositories))
+		}
+	}
 
-	if err := hooks.PostFetch(cfg.WorkspaceRoot, result.SuccessCount()); err != nil {
+	if err := hooks.PostFetch(cfg.WorkspaceRoot, result.SuccessNames(), export.NewIncomingOutput(incoming.Repositories, incoming.FetchedAt).Repositories); err != nil {
 		return fmt.Errorf("post-fetch hook failed: %w", err)
 	}
 
-	return nil
+	return exitcode.FromResult(result, "fetch")
 }
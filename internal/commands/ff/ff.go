@@ -3,14 +3,13 @@ package ff
 import (
 	"fmt"
 	"log/slog"
-	"path/filepath"
 
+	"gogws/internal/actions"
 	"gogws/internal/config"
-	"gogws/internal/engine"
-	"gogws/internal/git"
+	"gogws/internal/exitcode"
 	"gogws/internal/gws"
 	"gogws/internal/hooks"
-	"gogws/internal/ui/cli"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -29,11 +28,7 @@ func NewCommand(getConfig func() *config.Config) *cobra.Command {
 func runFF(getConfig func() *config.Config) error {
 	cfg := getConfig()
 	if cfg == nil {
-		return fmt.Errorf("no workspace found (no .projects.gws file)")
-	}
-
-	if err := hooks.PreFF(cfg.WorkspaceRoot); err != nil {
-		return fmt.Errorf("pre-ff hook failed: %w", err)
+		return exitcode.ErrNoWorkspace
 	}
 
 	slog.Debug("Running ff command", "workspace", cfg.WorkspaceRoot)
@@ -43,39 +38,27 @@ func runFF(getConfig func() *config.Config) error {
 		return fmt.Errorf("failed to load projects: %w", err)
 	}
 
-	renderer := cli.NewRenderer()
-	output := engine.NewOutputHandler(renderer, false)
-
-	commands := make([]engine.RepoCommand, 0, len(ws.Projects))
-	var skippedResults []engine.Result
-
-	for _, p := range ws.Projects {
-		repoPath := filepath.Join(cfg.WorkspaceRoot, p.Path)
-		status := git.GetStatus(repoPath)
-
-		if !status.Exists {
-			cmd := engine.NewGitCommand(repoPath, p.Path, "pull", "--ff-only")
-			skippedResults = append(skippedResults, engine.Skip(cmd, "not cloned yet"))
-			continue
-		}
-
-		commands = append(commands, engine.NewGitCommand(repoPath, p.Path, "pull", "--ff-only"))
+	out, err := output.New(cfg.Format)
+	if err != nil {
+		return err
 	}
 
-	result := engine.Execute(commands, engine.ExecuteOptions{
+	result, err := actions.FF(cfg.WorkspaceRoot, ws.Projects, actions.Options{
 		Parallel:    cfg.Parallel,
 		StopOnError: cfg.StopOnError,
+		Progress:    out.Progress,
 	})
-
-	for _, r := range skippedResults {
-		result.AddResult(r)
+	if err != nil {
+		return err
 	}
 
-	output.RenderSummary(result, "Pulled")
+	if err := out.Results(result, "ff", "Pulled"); err != nil {
+		return err
+	}
 
-	if err := hooks.PostFF(cfg.WorkspaceRoot, result.SuccessCount()); err != nil {
+	if err := hooks.PostFF(cfg.WorkspaceRoot, result.SuccessNames()); err != nil {
 		return fmt.Errorf("post-ff hook failed: %w", err)
 	}
 
-	return nil
+	return exitcode.FromResult(result, "ff")
 }
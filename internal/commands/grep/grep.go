@@ -0,0 +1,122 @@
+package grep
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ignoreCase   bool
+	wordRegexp   bool
+	fixedStrings bool
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grep <pattern> [project...] [-- pathspec...]",
+		Short: "Search tracked files across all repositories",
+		Long: `Run git grep in every repository (or the given projects) in parallel and
+combine the matches. Each match is printed as path:line:column:text with
+the path prefixed by the project path, so editors and terminals can open it
+directly from the workspace root.
+
+Arguments after -- are passed to git grep as pathspecs.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var pathspecs []string
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				if dash == 0 {
+					return exitcode.New(exitcode.Usage, fmt.Errorf("a pattern is required before --"))
+				}
+				args, pathspecs = args[:dash], args[dash:]
+			}
+			return runGrep(getConfig, args[0], args[1:], pathspecs)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&ignoreCase, "ignore-case", "i", false, "ignore case differences")
+	cmd.Flags().BoolVarP(&wordRegexp, "word-regexp", "w", false, "match the pattern only at word boundaries")
+	cmd.Flags().BoolVarP(&fixedStrings, "fixed-strings", "F", false, "treat the pattern as a literal string")
+
+	return cmd
+}
+
+func runGrep(getConfig func() *config.Config, pattern string, projectArgs, pathspecs []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running grep command", "workspace", cfg.WorkspaceRoot, "pattern", pattern)
+
+	out, err := output.NewForData(cfg.Format, "grep")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, projectArgs)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	opts := git.GrepOptions{
+		Pattern:      pattern,
+		IgnoreCase:   ignoreCase,
+		WordRegexp:   wordRegexp,
+		FixedStrings: fixedStrings,
+		Pathspecs:    pathspecs,
+	}
+
+	commands := make([]engine.RepoCommand, 0, len(projects))
+	for _, p := range projects {
+		if !p.Exists {
+			continue
+		}
+		repoPath := filepath.Join(cfg.WorkspaceRoot, p.Path)
+		commands = append(commands, engine.NewCustomCommand(repoPath, p.Path, func() (string, error) {
+			return git.Grep(repoPath, opts)
+		}))
+	}
+
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: cfg.Parallel})
+
+	var matches []git.GrepMatch
+	for _, r := range result.Succeeded() {
+		matches = append(matches, git.ParseGrep(r.Command.RepoName, r.Stdout)...)
+	}
+
+	if !out.IsText() {
+		if err := out.Data("grep", export.NewGrepOutput(pattern, matches, result.Failed())); err != nil {
+			return err
+		}
+		return exitcode.FromResult(result, "grep")
+	}
+
+	for _, r := range result.Failed() {
+		out.Warning(fmt.Sprintf("%s: %s", r.Command.RepoName, export.ResultError(r)))
+	}
+
+	if len(matches) == 0 {
+		out.Info("No matches found")
+	} else {
+		fmt.Fprint(output.Stdout(), out.Renderer().RenderGrep(matches))
+	}
+
+	return exitcode.FromResult(result, "grep")
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"gogws/internal/output"
+
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -35,7 +37,7 @@ func HelpFunc(command *cobra.Command, args []string) {
 
 	if true {
 		helpTemplated := command.UseLine()
-		fmt.Println(renderWithGlamour(helpTemplated))
+		fmt.Fprintln(output.Stdout(), renderWithGlamour(helpTemplated))
 	}
 
 	originalLong := command.Long
@@ -81,6 +83,9 @@ func HelpFunc(command *cobra.Command, args []string) {
 	if len(command.Aliases) > 0 {
 		helpEntries = append(helpEntries, helpEntry{"ALIASES", strings.Join(command.Aliases, ", ")})
 	}
+	if expansion, ok := command.Annotations["help:alias"]; ok {
+		helpEntries = append(helpEntries, helpEntry{"EXPANDS TO", expansion})
+	}
 	//if len(coreCommands) > 0 {
 	//	helpEntries = append(helpEntries, helpEntry{"CORE COMMANDS", strings.Join(coreCommands, "\n")})
 	//}
@@ -0,0 +1,63 @@
+package incoming
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gogws/internal/cache"
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "incoming",
+		Short: "Show what changed upstream in the last fetch",
+		Long: `Show the report recorded by the last "gogws fetch": new commits per
+remote-tracking branch, force-updated (rewritten) branches, new tags and
+deleted remote branches, grouped by repository.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIncoming(getConfig)
+		},
+	}
+}
+
+func runIncoming(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running incoming command", "workspace", cfg.WorkspaceRoot)
+
+	out, err := output.NewForData(cfg.Format, "incoming")
+	if err != nil {
+		return err
+	}
+
+	incoming, err := cache.LoadIncoming(cfg.WorkspaceRoot)
+	if err != nil {
+		return err
+	}
+	if incoming == nil {
+		return fmt.Errorf("no fetch has been recorded yet; run 'gogws fetch' first")
+	}
+
+	if !out.IsText() {
+		return out.Data("incoming", export.NewIncomingOutput(incoming.Repositories, incoming.FetchedAt))
+	}
+
+	out.Text(out.Renderer().RenderHeader(fmt.Sprintf("Incoming changes (fetched %s)", incoming.FetchedAt.Local().Format("2006-01-02 15:04"))))
+	if len(incoming.Repositories) == 0 {
+		out.Info("No incoming changes")
+		return nil
+	}
+
+	fmt.Fprint(output.Stdout(), out.Renderer().RenderIncoming(incoming.Repositories))
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 
 	"gogws/internal/gitignore"
-	"gogws/internal/ui/cli"
 
 	"github.com/spf13/cobra"
 )
@@ -46,8 +45,13 @@ func runInitGitignore() error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	renderer := cli.NewRenderer()
+	out, result, err := newOutput("gitignore")
+	if err != nil {
+		return err
+	}
+
 	gitignorePath := filepath.Join(workspaceRoot, ".gitignore")
+	result.File = gitignorePath
 
 	if removeGitignore {
 		hasSection, err := gitignore.HasGWSSection(gitignorePath)
@@ -56,24 +60,29 @@ func runInitGitignore() error {
 		}
 
 		if !hasSection {
-			fmt.Println(renderer.RenderWarning("No GWS section found in .gitignore"))
-			return nil
+			out.Warning("No GWS section found in .gitignore")
+			result.Gitignore = "unchanged"
+			result.Message = "no GWS section found"
+			return finish(out, result)
 		}
 
 		if err := gitignore.RemoveGWSSection(gitignorePath); err != nil {
 			return fmt.Errorf("failed to remove GWS section: %w", err)
 		}
 
-		fmt.Println(renderer.RenderSuccess("Removed GWS section from .gitignore"))
-		return nil
+		out.Success("Removed GWS section from .gitignore")
+		result.Gitignore = "removed"
+		return finish(out, result)
 	}
 
 	if _, err := os.Stat(gitignorePath); os.IsNotExist(err) {
 		if err := gitignore.CreateGitignore(workspaceRoot); err != nil {
 			return fmt.Errorf("failed to create .gitignore: %w", err)
 		}
-		fmt.Println(renderer.RenderSuccess("Created .gitignore with GWS configuration"))
-		return nil
+		out.Success("Created .gitignore with GWS configuration")
+		result.Created = true
+		result.Gitignore = "created"
+		return finish(out, result)
 	}
 
 	hasSection, err := gitignore.HasGWSSection(gitignorePath)
@@ -82,9 +91,11 @@ func runInitGitignore() error {
 	}
 
 	if hasSection && !forceGitignore {
-		fmt.Println(renderer.RenderInfo("GWS section already exists in .gitignore"))
-		fmt.Println(renderer.RenderInfo("Use --force to update it"))
-		return nil
+		out.Info("GWS section already exists in .gitignore")
+		out.Info("Use --force to update it")
+		result.Gitignore = "unchanged"
+		result.Message = "GWS section already exists"
+		return finish(out, result)
 	}
 
 	if err := gitignore.EnsureGWSSection(workspaceRoot); err != nil {
@@ -92,10 +103,12 @@ func runInitGitignore() error {
 	}
 
 	if hasSection {
-		fmt.Println(renderer.RenderSuccess("Updated GWS section in .gitignore"))
+		out.Success("Updated GWS section in .gitignore")
+		result.Gitignore = "updated"
 	} else {
-		fmt.Println(renderer.RenderSuccess("Added GWS section to .gitignore"))
+		out.Success("Added GWS section to .gitignore")
+		result.Gitignore = "added"
 	}
 
-	return nil
+	return finish(out, result)
 }
@@ -2,6 +2,8 @@ package initcmd
 
 import (
 	"gogws/internal/config"
+	"gogws/internal/export"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -30,3 +32,23 @@ Running 'gogws init' without subcommand is equivalent to 'gogws init projects'.`
 
 	return cmd
 }
+
+func newOutput(target string) (*output.Writer, export.InitOutput, error) {
+	format := ""
+	if resolved := config.GetResolved(); resolved != nil {
+		format = resolved.Format.Value
+	}
+
+	out, err := output.NewForData(format, "init")
+	if err != nil {
+		return nil, export.InitOutput{}, err
+	}
+	return out, export.NewInitOutput(target), nil
+}
+
+func finish(out *output.Writer, result export.InitOutput) error {
+	if out.IsText() {
+		return nil
+	}
+	return out.Data("init", result)
+}
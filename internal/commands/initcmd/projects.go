@@ -8,11 +8,11 @@ import (
 	"strings"
 
 	"gogws/internal/config"
+	"gogws/internal/export"
 	"gogws/internal/git"
 	"gogws/internal/gitignore"
 	"gogws/internal/gws"
 	"gogws/internal/hooks"
-	"gogws/internal/ui/cli"
 
 	"github.com/spf13/cobra"
 )
@@ -53,7 +53,10 @@ func runInitProjects(getConfig func() *config.Config) error {
 
 	slog.Debug("Initializing workspace", "path", workspaceRoot)
 
-	renderer := cli.NewRenderer()
+	out, result, err := newOutput("projects")
+	if err != nil {
+		return err
+	}
 
 	gwsDir := filepath.Join(workspaceRoot, gws.ConfigDirName)
 	if err := os.MkdirAll(gwsDir, 0755); err != nil {
@@ -73,19 +76,21 @@ func runInitProjects(getConfig func() *config.Config) error {
 
 	if fileExists {
 		if resetProjectsGwsFile {
-			fmt.Println(renderer.RenderWarning(fmt.Sprintf("Removing existing %s", projectsFile)))
+			out.Warning(fmt.Sprintf("Removing existing %s", projectsFile))
 			if err := os.Remove(projectsFile); err != nil {
 				return fmt.Errorf("failed to remove existing %s: %w", projectsFile, err)
 			}
-			fmt.Println(renderer.RenderSuccess(fmt.Sprintf("Removed existing %s", projectsFile)))
+			out.Success(fmt.Sprintf("Removed existing %s", projectsFile))
 			projectsFile = filepath.Join(gwsDir, "projects."+gws.FileExtension)
 		} else {
-			fmt.Println(renderer.RenderError(fmt.Sprintf("projects.%s already exists. Use --reset to reinitialize", gws.FileExtension)))
-			return nil
+			out.Error(fmt.Sprintf("projects.%s already exists. Use --reset to reinitialize", gws.FileExtension))
+			result.File = projectsFile
+			result.Message = "already exists"
+			return finish(out, result)
 		}
 	}
 
-	fmt.Println(renderer.RenderInfo("Scanning workspace for git repositories..."))
+	out.Info("Scanning workspace for git repositories...")
 
 	discovered, err := git.DiscoverRepositories(workspaceRoot, 10)
 	if err != nil {
@@ -93,8 +98,9 @@ func runInitProjects(getConfig func() *config.Config) error {
 	}
 
 	if len(discovered) == 0 {
-		fmt.Println(renderer.RenderWarning("No git repositories found in workspace"))
-		return nil
+		out.Warning("No git repositories found in workspace")
+		result.Message = "no git repositories found"
+		return finish(out, result)
 	}
 
 	slog.Debug("Found repositories", "count", len(discovered))
@@ -106,7 +112,7 @@ func runInitProjects(getConfig func() *config.Config) error {
 			Remotes: toGwsRemotes(d.Remotes),
 		}
 	}
-	fmt.Println(renderer.RenderProjectsList(projects))
+	out.Text(out.Renderer().RenderProjectsList(projects))
 
 	file, err := os.Create(projectsFile)
 	if err != nil {
@@ -117,23 +123,30 @@ func runInitProjects(getConfig func() *config.Config) error {
 	var projectPaths []string
 	for _, project := range projects {
 		projectPaths = append(projectPaths, project.Path)
+		entry := export.InitEntryOutput{Path: project.Path}
 		var remoteParts []string
 		for _, remote := range project.Remotes {
 			remoteParts = append(remoteParts, fmt.Sprintf("%s %s", remote.URL, remote.Name))
+			entry.Remotes = append(entry.Remotes, export.RemoteOutput{Name: remote.Name, URL: remote.URL})
 		}
+		result.Entries = append(result.Entries, entry)
 		line := fmt.Sprintf("%s | %s\n", project.Path, strings.Join(remoteParts, " | "))
 		if _, err := file.WriteString(line); err != nil {
 			return fmt.Errorf("failed to write to %s: %w", projectsFile, err)
 		}
 	}
 
-	fmt.Println(renderer.RenderSuccess(fmt.Sprintf("Created %s with %d repositories", projectsFile, len(projects))))
+	out.Success(fmt.Sprintf("Created %s with %d repositories", projectsFile, len(projects)))
+	result.File = projectsFile
+	result.Created = true
 
 	if generateGitignore {
 		if err := gitignore.EnsureGWSSection(workspaceRoot); err != nil {
-			fmt.Println(renderer.RenderWarning(fmt.Sprintf("Failed to generate .gitignore: %v", err)))
+			out.Warning(fmt.Sprintf("Failed to generate .gitignore: %v", err))
+			result.Gitignore = "failed"
 		} else {
-			fmt.Println(renderer.RenderSuccess("Generated .gitignore"))
+			out.Success("Generated .gitignore")
+			result.Gitignore = "generated"
 		}
 	}
 
@@ -141,7 +154,7 @@ func runInitProjects(getConfig func() *config.Config) error {
 		return fmt.Errorf("post-init hook failed: %w", err)
 	}
 
-	return nil
+	return finish(out, result)
 }
 
 func toGwsRemotes(remotes []git.Remote) []gws.Remote {
@@ -8,9 +8,9 @@ import (
 	"strings"
 
 	"gogws/internal/config"
+	"gogws/internal/export"
 	"gogws/internal/gitignore"
 	"gogws/internal/gws"
-	"gogws/internal/ui/cli"
 
 	"github.com/spf13/cobra"
 )
@@ -44,7 +44,11 @@ func runInitWorkspaces() error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	renderer := cli.NewRenderer()
+	out, result, err := newOutput("workspaces")
+	if err != nil {
+		return err
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	entries, err := os.ReadDir(workspaceRoot)
@@ -60,20 +64,21 @@ func runInitWorkspaces() error {
 	}
 
 	if len(subdirs) == 0 {
-		fmt.Println(renderer.RenderWarning("No subdirectories found"))
-		return nil
+		out.Warning("No subdirectories found")
+		result.Message = "no subdirectories found"
+		return finish(out, result)
 	}
 
-	fmt.Println(renderer.RenderInfo("Found subdirectories:"))
+	out.Info("Found subdirectories:")
 	for i, dir := range subdirs {
-		fmt.Printf("  [%d] %s\n", i+1, dir)
+		out.Prompt(fmt.Sprintf("  [%d] %s\n", i+1, dir))
 	}
-	fmt.Println()
+	out.Prompt("\n")
 
 	var selectedWorkspaces []workspaceEntry
 
 	for _, dir := range subdirs {
-		fmt.Printf("Configure '%s' as a workspace? [y/N/q]: ", dir)
+		out.Prompt(fmt.Sprintf("Configure '%s' as a workspace? [y/N/q]: ", dir))
 		input, err := reader.ReadString('\n')
 		if err != nil {
 			return err
@@ -88,7 +93,7 @@ func runInitWorkspaces() error {
 			continue
 		}
 
-		fmt.Printf("  Git remote URL for '%s' (or press Enter to skip): ", dir)
+		out.Prompt(fmt.Sprintf("  Git remote URL for '%s' (or press Enter to skip): ", dir))
 		urlInput, err := reader.ReadString('\n')
 		if err != nil {
 			return err
@@ -106,12 +111,13 @@ func runInitWorkspaces() error {
 		}
 
 		selectedWorkspaces = append(selectedWorkspaces, entry)
-		fmt.Println(renderer.RenderSuccess(fmt.Sprintf("  Added '%s'", dir)))
+		out.Success(fmt.Sprintf("  Added '%s'", dir))
 	}
 
 	if len(selectedWorkspaces) == 0 {
-		fmt.Println(renderer.RenderWarning("No workspaces configured"))
-		return nil
+		out.Warning("No workspaces configured")
+		result.Message = "no workspaces configured"
+		return finish(out, result)
 	}
 
 	gwsDir := filepath.Join(workspaceRoot, gws.ConfigDirName)
@@ -128,6 +134,12 @@ func runInitWorkspaces() error {
 	defer file.Close()
 
 	for _, ws := range selectedWorkspaces {
+		entry := export.InitEntryOutput{Path: ws.Path}
+		if ws.RemoteURL != "" {
+			entry.Remotes = []export.RemoteOutput{{Name: ws.RemoteName, URL: ws.RemoteURL}}
+		}
+		result.Entries = append(result.Entries, entry)
+
 		var line string
 		if ws.RemoteURL != "" {
 			line = fmt.Sprintf("%s | %s %s\n", ws.Path, ws.RemoteURL, ws.RemoteName)
@@ -139,18 +151,22 @@ func runInitWorkspaces() error {
 		}
 	}
 
-	fmt.Println()
-	fmt.Println(renderer.RenderSuccess(fmt.Sprintf("Created %s with %d workspaces", workspacesFile, len(selectedWorkspaces))))
+	out.Text("")
+	out.Success(fmt.Sprintf("Created %s with %d workspaces", workspacesFile, len(selectedWorkspaces)))
+	result.File = workspacesFile
+	result.Created = true
 
 	if workspacesGitignore {
 		if err := gitignore.EnsureGWSSection(workspaceRoot); err != nil {
-			fmt.Println(renderer.RenderWarning(fmt.Sprintf("Failed to generate .gitignore: %v", err)))
+			out.Warning(fmt.Sprintf("Failed to generate .gitignore: %v", err))
+			result.Gitignore = "failed"
 		} else {
-			fmt.Println(renderer.RenderSuccess("Generated .gitignore"))
+			out.Success("Generated .gitignore")
+			result.Gitignore = "generated"
 		}
 	}
 
-	return nil
+	return finish(out, result)
 }
 
 type workspaceEntry struct {
@@ -0,0 +1,150 @@
+package logcmd
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	since  string
+	author string
+	grep   string
+	change string
+	remote bool
+	limit  int
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log [project...]",
+		Short: "Show one commit timeline across all repositories",
+		Long: `Collect commits from every repository (or the given projects) and merge
+them into a single timeline, newest first. Each entry is tagged with its
+repository.
+
+With --remote, only commits on remote-tracking branches that are not on any
+local branch are shown: what is new upstream since the last fetch.
+
+With --change, commits made together by "gogws commit" are found by their
+shared change identifier, on any local or remote-tracking branch.
+
+--since accepts a relative age (30m, 12h, 2d, 1w) or any date git understands
+("2024-01-31", "last monday").`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLog(getConfig, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "only show commits newer than this age or date")
+	cmd.Flags().StringVar(&author, "author", "", "only show commits whose author matches this pattern")
+	cmd.Flags().StringVar(&grep, "grep", "", "only show commits whose message matches this pattern (case-insensitive)")
+	cmd.Flags().StringVar(&change, "change", "", "only show commits carrying this change identifier")
+	cmd.Flags().BoolVar(&remote, "remote", false, "show upstream commits not yet on any local branch")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "maximum number of commits to show (0 for no limit)")
+	cmd.MarkFlagsMutuallyExclusive("grep", "change")
+
+	return cmd
+}
+
+func runLog(getConfig func() *config.Config, args []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running log command", "workspace", cfg.WorkspaceRoot)
+
+	out, err := output.NewForData(cfg.Format, "log")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, args)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	gitArgs := git.LogArgs(git.LogOptions{
+		Since:  parseSince(since),
+		Author: author,
+		Grep:   grep,
+		Change: change,
+		Remote: remote,
+		Limit:  limit,
+	})
+
+	commands := make([]engine.RepoCommand, 0, len(projects))
+	for _, p := range projects {
+		if p.Exists {
+			commands = append(commands, engine.NewGitCommand(filepath.Join(cfg.WorkspaceRoot, p.Path), p.Path, gitArgs...))
+		}
+	}
+
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: cfg.Parallel})
+
+	logs := make([][]git.LogEntry, 0, len(result.Results))
+	for _, r := range result.Succeeded() {
+		logs = append(logs, git.ParseLog(r.Command.RepoName, r.Stdout))
+	}
+
+	entries := git.MergeLogs(logs)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	if !out.IsText() {
+		if err := out.Data("log", export.NewLogOutput(entries, result.Failed(), remote)); err != nil {
+			return err
+		}
+		return exitcode.FromResult(result, "log")
+	}
+
+	for _, r := range result.Failed() {
+		out.Warning(fmt.Sprintf("%s: %s", r.Command.RepoName, export.ResultError(r)))
+	}
+
+	if len(entries) == 0 {
+		out.Info("No commits found")
+	} else {
+		fmt.Fprint(output.Stdout(), out.Renderer().RenderLog(entries))
+	}
+
+	return exitcode.FromResult(result, "log")
+}
+
+var relativeAge = regexp.MustCompile(`^(\d+)([mhdwy])$`)
+
+var ageUnits = map[string]string{
+	"m": "minutes",
+	"h": "hours",
+	"d": "days",
+	"w": "weeks",
+	"y": "years",
+}
+
+func parseSince(value string) string {
+	m := relativeAge.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+	n, _ := strconv.Atoi(m[1])
+	return fmt.Sprintf("%d %s ago", n, ageUnits[m[2]])
+}
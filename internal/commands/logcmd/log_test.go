@@ -0,0 +1,20 @@
+package logcmd
+
+import "testing"
+
+func TestParseSince(t *testing.T) {
+	tests := map[string]string{
+		"":           "",
+		"2d":         "2 days ago",
+		"30m":        "30 minutes ago",
+		"1w":         "1 weeks ago",
+		"12h":        "12 hours ago",
+		"2024-01-31": "2024-01-31",
+		"last week":  "last week",
+	}
+	for in, want := range tests {
+		if got := parseSince(in); got != want {
+			t.Errorf("parseSince(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
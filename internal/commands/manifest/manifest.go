@@ -0,0 +1,125 @@
+package manifest
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var resolved bool
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Inspect the projects and workspaces files",
+	}
+
+	show := &cobra.Command{
+		Use:   "show",
+		Short: "Show the projects and workspaces of the workspace",
+		Long: `Show the projects and nested workspaces defined for this workspace.
+
+Includes are resolved and the flattened result is shown, with the file and
+line each project was defined on. When a path is defined more than once, the
+later definition wins.
+
+URLs are shown as written. With --resolved, ${NAME} variables are expanded
+and url.<base>.instead-of rules from the configuration are applied, showing
+the URLs used for cloning and remotes sync.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(getConfig)
+		},
+	}
+
+	show.Flags().BoolVar(&resolved, "resolved", false, "show the effective URLs")
+
+	cmd.AddCommand(show)
+	return cmd
+}
+
+func runShow(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running manifest show command", "workspace", cfg.WorkspaceRoot, "resolved", resolved)
+
+	out, err := output.NewForData(cfg.Format, "manifest")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load workspace: %w", err)
+	}
+
+	manifest := export.NewManifestOutput(ws, resolved)
+
+	if !out.IsText() {
+		if err := out.Data("manifest", manifest); err != nil {
+			return err
+		}
+	} else {
+		columns := []string{"path", "remote", "url"}
+		if resolved {
+			columns = append(columns, "written")
+		}
+		if len(manifest.Projects) > 0 {
+			fmt.Fprintln(output.Stdout(), out.Renderer().RenderColumns(append(columns, "origin"), rows(manifest.Projects, true)))
+		}
+		if len(manifest.Workspaces) > 0 {
+			fmt.Fprintln(output.Stdout())
+			out.Info("Workspaces")
+			fmt.Fprintln(output.Stdout(), out.Renderer().RenderColumns(columns, rows(manifest.Workspaces, false)))
+		}
+	}
+
+	var unresolved []string
+	for _, entries := range [][]export.ManifestEntryOutput{manifest.Projects, manifest.Workspaces} {
+		for _, e := range entries {
+			for _, r := range e.Remotes {
+				if len(r.Unresolved) > 0 {
+					unresolved = append(unresolved, fmt.Sprintf("%s (%s): %s", e.Path, r.Name, strings.Join(r.Unresolved, ", ")))
+				}
+			}
+		}
+	}
+	if len(unresolved) > 0 {
+		for _, u := range unresolved {
+			out.Error("Undefined variables in " + u)
+		}
+		return exitcode.New(exitcode.Failure, fmt.Errorf("manifest: %d URL(s) use undefined variables", len(unresolved)))
+	}
+	return nil
+}
+
+func rows(entries []export.ManifestEntryOutput, withOrigin bool) [][]string {
+	var rows [][]string
+	for _, e := range entries {
+		for _, r := range e.Remotes {
+			row := []string{e.Path, r.Name, r.URL}
+			if resolved {
+				written := "-"
+				if r.Spec != "" {
+					written = r.Spec
+				}
+				row = append(row, written)
+			}
+			if withOrigin {
+				row = append(row, e.Origin.String())
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
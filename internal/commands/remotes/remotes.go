@@ -0,0 +1,254 @@
+package remotes
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"gogws/internal/actions"
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncReverse bool
+	syncDryRun  bool
+
+	rewriteFrom   string
+	rewriteTo     string
+	rewriteVerify bool
+	rewriteYes    bool
+	rewriteDryRun bool
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remotes",
+		Short: "Manage git remotes across the workspace",
+	}
+
+	sync := &cobra.Command{
+		Use:   "sync [project...]",
+		Short: "Make repository remotes match the projects file",
+		Long: `Compare the remotes in the projects file with the remotes configured in
+each cloned repository, then add, rename, update or remove remotes so the
+repositories match the projects file.
+
+A remote whose URL matches a missing remote from the projects file is
+renamed, which keeps its remote-tracking branches.
+
+With --reverse, the projects file is updated from the remotes on disk
+instead. Repositories that are not cloned are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSync(getConfig, args)
+		},
+	}
+
+	sync.Flags().BoolVar(&syncReverse, "reverse", false, "write the remotes on disk back into the projects file")
+	sync.Flags().BoolVar(&syncDryRun, "dry-run", false, "only show the differences")
+
+	rewrite := &cobra.Command{
+		Use:   "rewrite",
+		Short: "Rewrite remote URLs in the projects files and cloned repositories",
+		Long: `Rewrite every remote URL matching the --from regular expression, using
+--to as the replacement ($1 or ${name} refer to capture groups).
+
+The URLs are rewritten in all projects and workspaces files of the
+workspace and its nested workspaces, keeping comments and layout, and in
+the remotes of every cloned repository. The changes are shown first and
+applied after confirmation.
+
+With --verify, every new URL is checked with git ls-remote before anything
+is changed.`,
+		Example: `  gogws remotes rewrite --from git.old-corp.com --to git.new-corp.com
+  gogws remotes rewrite --from '^https://git.new-corp.com/(.*)$' --to 'git@git.new-corp.com:$1' --verify`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRewrite(getConfig)
+		},
+	}
+
+	rewrite.Flags().StringVar(&rewriteFrom, "from", "", "regular expression matching the URLs to rewrite")
+	rewrite.Flags().StringVar(&rewriteTo, "to", "", "replacement for the matched part of the URL")
+	rewrite.Flags().BoolVar(&rewriteVerify, "verify", false, "check that the new URLs are reachable with git ls-remote")
+	rewrite.Flags().BoolVarP(&rewriteYes, "yes", "y", false, "apply without asking for confirmation")
+	rewrite.Flags().BoolVar(&rewriteDryRun, "dry-run", false, "only show what would change")
+	_ = rewrite.MarkFlagRequired("from")
+	_ = rewrite.MarkFlagRequired("to")
+
+	cmd.AddCommand(sync, rewrite)
+	return cmd
+}
+
+func runSync(getConfig func() *config.Config, args []string) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running remotes sync command", "workspace", cfg.WorkspaceRoot, "reverse", syncReverse)
+
+	out, err := output.NewForData(cfg.Format, "remotes")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Recursive(false).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err := gws.SelectProjects(ws.Projects, args)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+
+	var results []export.RemoteSyncResult
+	var updated []gws.Project
+	for _, p := range projects {
+		if !p.Exists {
+			continue
+		}
+		changes, actual, err := actions.RemoteDrift(cfg.WorkspaceRoot, p, syncReverse)
+		if err != nil {
+			results = append(results, export.RemoteSyncResult{Path: p.Path, State: export.RemotesFailed, Error: err.Error()})
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		result := export.RemoteSyncResult{Path: p.Path, Changes: changes, State: export.RemotesPending}
+		if syncReverse {
+			p.Remotes = actions.ManifestRemotes(p, actual)
+			result.Line = gws.FormatProjectLine(p)
+			updated = append(updated, p)
+		}
+		results = append(results, result)
+	}
+
+	if out.IsText() {
+		if len(results) == 0 {
+			out.Success("All remotes match the projects file")
+			return nil
+		}
+		fmt.Fprintln(output.Stdout(), out.Renderer().RenderColumns([]string{"path", "action", "remote", "url"}, rows(results)))
+		fmt.Fprintln(output.Stdout())
+		if syncReverse {
+			out.Info("Projects file entries:")
+			for _, r := range results {
+				if r.Line != "" {
+					out.Text("  " + r.Line)
+				}
+			}
+			fmt.Fprintln(output.Stdout())
+		}
+	}
+
+	if !syncDryRun {
+		if syncReverse {
+			writeManifest(cfg.WorkspaceRoot, results, updated)
+		} else {
+			syncRepositories(cfg.WorkspaceRoot, results)
+		}
+	}
+
+	if !out.IsText() {
+		if err := out.Data("remotes", export.NewRemotesSyncOutput(results, syncReverse, syncDryRun)); err != nil {
+			return err
+		}
+	} else {
+		report(out, results)
+	}
+
+	for _, r := range results {
+		if r.State == export.RemotesFailed {
+			return exitcode.New(exitcode.PartialFailure, fmt.Errorf("remotes: some repositories could not be synced"))
+		}
+	}
+	return nil
+}
+
+func rows(results []export.RemoteSyncResult) [][]string {
+	var rows [][]string
+	for _, r := range results {
+		if r.State == export.RemotesFailed {
+			rows = append(rows, []string{r.Path, "failed", "-", r.Error})
+			continue
+		}
+		for _, c := range r.Changes {
+			remote, url := c.Name, c.URL
+			switch c.Action {
+			case git.RemoteRename:
+				remote = c.OldName + " -> " + c.Name
+			case git.RemoteSetURL:
+				url = c.OldURL + " -> " + c.URL
+			case git.RemoteRemove:
+				url = c.OldURL
+			}
+			rows = append(rows, []string{r.Path, string(c.Action), remote, url})
+		}
+	}
+	return rows
+}
+
+func syncRepositories(workspaceRoot string, results []export.RemoteSyncResult) {
+	for i := range results {
+		r := &results[i]
+		if r.State != export.RemotesPending {
+			continue
+		}
+		r.State = export.RemotesSynced
+		for _, c := range r.Changes {
+			if err := git.ApplyRemoteChange(filepath.Join(workspaceRoot, r.Path), c); err != nil {
+				r.State, r.Error = export.RemotesFailed, err.Error()
+				break
+			}
+		}
+	}
+}
+
+func writeManifest(workspaceRoot string, results []export.RemoteSyncResult, updated []gws.Project) {
+	byPath := make(map[string]gws.Project, len(updated))
+	for _, p := range updated {
+		byPath[p.Path] = p
+	}
+
+	for i := range results {
+		r := &results[i]
+		if r.State != export.RemotesPending {
+			continue
+		}
+		if err := gws.UpdateProject(workspaceRoot, byPath[r.Path]); err != nil {
+			r.State, r.Error = export.RemotesFailed, err.Error()
+			continue
+		}
+		r.State = export.RemotesSynced
+	}
+}
+
+func report(out *output.Writer, results []export.RemoteSyncResult) {
+	synced := 0
+	for _, r := range results {
+		switch r.State {
+		case export.RemotesSynced:
+			synced++
+		case export.RemotesFailed:
+			out.Error(fmt.Sprintf("%s: %s", r.Path, r.Error))
+		}
+	}
+
+	switch {
+	case syncDryRun:
+		out.Info(fmt.Sprintf("%d repositories differ from the projects file", len(results)))
+	case syncReverse:
+		out.Success(fmt.Sprintf("Updated %d project(s) in the projects file", synced))
+	default:
+		out.Success(fmt.Sprintf("Synced remotes of %d repositories", synced))
+	}
+}
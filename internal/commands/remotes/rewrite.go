@@ -0,0 +1,313 @@
+package remotes
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+)
+
+func runRewrite(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running remotes rewrite command", "workspace", cfg.WorkspaceRoot, "from", rewriteFrom, "to", rewriteTo)
+
+	pattern, err := regexp.Compile(rewriteFrom)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, fmt.Errorf("invalid --from pattern: %w", err))
+	}
+	rewriteURL := func(url string) string {
+		return pattern.ReplaceAllString(url, rewriteTo)
+	}
+	resolved := config.GetResolved()
+	resolveURL := func(url string) string {
+		if resolved == nil {
+			return url
+		}
+		expanded, _ := resolved.ResolveURL(url)
+		return expanded
+	}
+
+	out, err := output.NewForData(cfg.Format, "remotes-rewrite")
+	if err != nil {
+		return err
+	}
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load workspace: %w", err)
+	}
+
+	var manifests []export.ManifestEditOutput
+	var skipped []export.ManifestSkipOutput
+	kept := make(map[string]bool)
+	for _, file := range gws.ManifestFiles(ws) {
+		edits, skips, err := gws.RewriteManifestURLs(file, rewriteURL, resolveURL, false)
+		if err != nil {
+			return err
+		}
+		for _, e := range edits {
+			manifests = append(manifests, export.ManifestEditOutput{
+				File: relPath(cfg.WorkspaceRoot, e.File), Line: e.Line, Old: e.Old, New: e.New, URLs: e.URLs, State: export.RemotesPending,
+			})
+		}
+		for _, s := range skips {
+			skip := export.ManifestSkipOutput{File: relPath(cfg.WorkspaceRoot, s.File), Line: s.Line, URL: s.Spec, Resolved: s.URL, DefinedBy: []string{}}
+			if resolved != nil {
+				skip.DefinedBy = resolved.URLSources(s.Spec)
+			}
+			skipped = append(skipped, skip)
+			kept[s.URL] = true
+		}
+	}
+
+	var repos []export.RemoteURLRewrite
+	for _, repoPath := range clonedRepositories(ws) {
+		remotes, err := git.GetRemotes(repoPath)
+		if err != nil {
+			out.Warning(fmt.Sprintf("%s: %v", relPath(cfg.WorkspaceRoot, repoPath), err))
+			continue
+		}
+		for _, r := range remotes {
+			if kept[r.URL] {
+				continue
+			}
+			if url := rewriteURL(r.URL); url != r.URL {
+				repos = append(repos, export.RemoteURLRewrite{
+					Path: relPath(cfg.WorkspaceRoot, repoPath), Remote: r.Name, Old: r.URL, New: url, State: export.RemotesPending,
+				})
+			}
+		}
+	}
+
+	if out.IsText() {
+		for _, s := range skipped {
+			out.Warning(fmt.Sprintf("%s:%d: %s is defined by %s; left unchanged, update it there and run gogws remotes sync",
+				s.File, s.Line, s.URL, strings.Join(s.DefinedBy, " and ")))
+		}
+	}
+
+	if len(manifests) == 0 && len(repos) == 0 {
+		if !out.IsText() {
+			return out.Data("remotes-rewrite", export.NewRemotesRewriteOutput(rewriteFrom, rewriteTo, nil, skipped, nil, nil, rewriteDryRun))
+		}
+		if len(skipped) == 0 {
+			out.Info(fmt.Sprintf("No URLs match %s", rewriteFrom))
+		}
+		return nil
+	}
+
+	if out.IsText() {
+		printPlan(out, manifests, repos)
+	}
+
+	var verified []export.URLCheck
+	if rewriteVerify {
+		verified = verifyURLs(cfg.Parallel, manifests, repos)
+		if out.IsText() {
+			for _, c := range verified {
+				if c.Reachable {
+					out.Success(fmt.Sprintf("%s is reachable", c.URL))
+				} else {
+					out.Error(fmt.Sprintf("%s is not reachable: %s", c.URL, c.Error))
+				}
+			}
+			fmt.Fprintln(output.Stdout())
+		}
+	}
+
+	unreachable := 0
+	for _, c := range verified {
+		if !c.Reachable {
+			unreachable++
+		}
+	}
+
+	if !rewriteDryRun && unreachable == 0 {
+		if !rewriteYes && !confirm(out, len(manifests)+len(repos)) {
+			out.Info("Aborted, nothing changed")
+			return nil
+		}
+		applyManifests(cfg.WorkspaceRoot, rewriteURL, resolveURL, manifests)
+		applyRepositories(cfg.WorkspaceRoot, repos)
+	}
+
+	if !out.IsText() {
+		if err := out.Data("remotes-rewrite", export.NewRemotesRewriteOutput(rewriteFrom, rewriteTo, manifests, skipped, repos, verified, rewriteDryRun)); err != nil {
+			return err
+		}
+	} else if rewriteDryRun {
+		out.Info(fmt.Sprintf("Dry run, %d change(s) not applied", len(manifests)+len(repos)))
+	} else if unreachable == 0 {
+		reportRewrite(out, manifests, repos)
+	}
+
+	if unreachable > 0 {
+		return exitcode.New(exitcode.Failure, fmt.Errorf("remotes rewrite: %d new URL(s) are not reachable, nothing changed", unreachable))
+	}
+	for _, m := range manifests {
+		if m.State == export.RemotesFailed {
+			return exitcode.New(exitcode.PartialFailure, fmt.Errorf("remotes rewrite: some changes could not be applied"))
+		}
+	}
+	for _, r := range repos {
+		if r.State == export.RemotesFailed {
+			return exitcode.New(exitcode.PartialFailure, fmt.Errorf("remotes rewrite: some changes could not be applied"))
+		}
+	}
+	return nil
+}
+
+func clonedRepositories(ws *gws.Workspace) []string {
+	var repos []string
+	if _, err := os.Stat(filepath.Join(ws.Root, ".git")); err == nil {
+		repos = append(repos, ws.Root)
+	}
+	for _, p := range ws.Projects {
+		if p.Exists {
+			repos = append(repos, filepath.Join(ws.Root, p.Path))
+		}
+	}
+	for _, child := range ws.Children {
+		if child.Exists && child.Root != "" {
+			repos = append(repos, clonedRepositories(child)...)
+		}
+	}
+	return repos
+}
+
+func printPlan(out *output.Writer, manifests []export.ManifestEditOutput, repos []export.RemoteURLRewrite) {
+	if len(manifests) > 0 {
+		edits := make([]gws.ManifestEdit, len(manifests))
+		for i, m := range manifests {
+			edits[i] = gws.ManifestEdit{File: m.File, Line: m.Line, Old: m.Old, New: m.New}
+		}
+		fmt.Fprint(output.Stdout(), out.Renderer().RenderManifestEdits(edits))
+		fmt.Fprintln(output.Stdout())
+	}
+
+	if len(repos) > 0 {
+		rows := make([][]string, len(repos))
+		for i, r := range repos {
+			rows[i] = []string{r.Path, r.Remote, r.Old + " -> " + r.New}
+		}
+		fmt.Fprintln(output.Stdout(), out.Renderer().RenderColumns([]string{"path", "remote", "url"}, rows))
+		fmt.Fprintln(output.Stdout())
+	}
+}
+
+func verifyURLs(parallel int, manifests []export.ManifestEditOutput, repos []export.RemoteURLRewrite) []export.URLCheck {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, r := range repos {
+		if !seen[r.New] {
+			seen[r.New] = true
+			urls = append(urls, r.New)
+		}
+	}
+	for _, m := range manifests {
+		for _, url := range m.URLs {
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	commands := make([]engine.RepoCommand, len(urls))
+	for i, url := range urls {
+		commands[i] = engine.NewCustomCommand("", url, func() (string, error) {
+			return "", git.LsRemote(url)
+		})
+	}
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: parallel})
+
+	checks := make([]export.URLCheck, 0, len(result.Results))
+	for _, r := range result.Results {
+		check := export.URLCheck{URL: r.Command.RepoName, Reachable: r.Success}
+		if !r.Success {
+			check.Error = export.ResultError(r)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func confirm(out *output.Writer, count int) bool {
+	out.Prompt(fmt.Sprintf("Apply %d change(s)? [y/N]: ", count))
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && input == "" {
+		return false
+	}
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
+
+func applyManifests(workspaceRoot string, rewriteURL, resolveURL func(string) string, manifests []export.ManifestEditOutput) {
+	done := make(map[string]error)
+	for i := range manifests {
+		m := &manifests[i]
+		err, ok := done[m.File]
+		if !ok {
+			_, _, err = gws.RewriteManifestURLs(filepath.Join(workspaceRoot, m.File), rewriteURL, resolveURL, true)
+			done[m.File] = err
+		}
+		if err != nil {
+			m.State, m.Error = export.RemotesFailed, err.Error()
+			continue
+		}
+		m.State = export.RemotesSynced
+	}
+}
+
+func applyRepositories(workspaceRoot string, repos []export.RemoteURLRewrite) {
+	for i := range repos {
+		r := &repos[i]
+		change := git.RemoteChange{Action: git.RemoteSetURL, Name: r.Remote, URL: r.New, OldURL: r.Old}
+		if err := git.ApplyRemoteChange(filepath.Join(workspaceRoot, r.Path), change); err != nil {
+			r.State, r.Error = export.RemotesFailed, err.Error()
+			continue
+		}
+		r.State = export.RemotesSynced
+	}
+}
+
+func reportRewrite(out *output.Writer, manifests []export.ManifestEditOutput, repos []export.RemoteURLRewrite) {
+	lines, remotes := 0, 0
+	for _, m := range manifests {
+		if m.State == export.RemotesSynced {
+			lines++
+		} else {
+			out.Error(fmt.Sprintf("%s:%d: %s", m.File, m.Line, m.Error))
+		}
+	}
+	for _, r := range repos {
+		if r.State == export.RemotesSynced {
+			remotes++
+		} else {
+			out.Error(fmt.Sprintf("%s: %s", r.Path, r.Error))
+		}
+	}
+	out.Success(fmt.Sprintf("Rewrote %d manifest line(s) and %d remote(s)", lines, remotes))
+}
+
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return rel
+	}
+	return path
+}
@@ -3,25 +3,32 @@ package root
 import (
 	"fmt"
 	"gogws/internal/config"
+	"gogws/internal/events"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
 	"gogws/internal/hooks"
 	"gogws/internal/log"
+	"gogws/internal/output"
+	"gogws/internal/theme"
 	"log/slog"
 	"os"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile     string
-	themeFile   string
-	parallel    int
-	format      string
-	noColor     bool
-	onlyChanges bool
-	verbose     bool
-	trustHooks  string
-	stopOnError bool
+	cfgFile      string
+	themeFile    string
+	parallel     int
+	format       string
+	noColor      bool
+	onlyChanges  bool
+	verbose      bool
+	trustHooks   string
+	stopOnError  bool
+	eventsFormat string
 )
 
 var rootCmd = &cobra.Command{
@@ -38,40 +45,116 @@ Compatible with gws project files (.projects.gws)`,
 func persistentPreRun(cmd *cobra.Command, args []string) error {
 	slog.Debug("persistentPreRun::")
 	log.SetVerbose(verbose)
-	hooks.SetTrustMode(hooks.ParseTrustMode(trustHooks))
 
-	if cmd.Name() == "config" {
-		return nil
+	if cfgFile != "" {
+		config.SetUserConfigPath(cfgFile)
+	}
+
+	initialize := config.Initialize
+	if isConfigCommand(cmd) {
+		initialize = config.InitializeSkippingInvalid
+	}
+	if err := initialize(flagOverrides(cmd)); err != nil {
+		return exitcode.New(exitcode.Usage, fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	resolved := config.GetResolved()
+	hooks.SetTrustMode(hooks.ParseTrustMode(resolved.TrustHooks.Value))
+	if !export.IsText(resolved.Format.Value) {
+		hooks.SetOutput(os.Stderr)
 	}
 
-	if err := config.Initialize(); err != nil {
-		slog.Debug(fmt.Sprintf("Config initialization skipped: %v", err))
+	if eventsFormat != "" {
+		if err := startEvents(cmd); err != nil {
+			return exitcode.New(exitcode.Usage, err)
+		}
+	}
+
+	if resolved.NoColor.Value {
+		lipgloss.SetColorProfile(termenv.Ascii)
 	}
 
-	if config.IsInitialized() {
-		config.ApplyFlags(themeFile, parallel, format, noColor, onlyChanges, stopOnError)
+	if cfg := config.GetConfig(); cfg != nil && cfg.ThemeFile != "" {
+		t, err := theme.LoadThemeFromFile(cfg.ThemeFile)
+		if err != nil {
+			slog.Warn("Failed to load theme, using default", "path", cfg.ThemeFile, "err", err)
+		} else {
+			theme.SetTheme(t)
+		}
 	}
 
 	return nil
 }
 
-func NewCommand() *cobra.Command {
-	cobra.OnInitialize(initConfig)
+func isConfigCommand(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Name() == "config" && !c.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+func startEvents(cmd *cobra.Command) error {
+	if err := events.ValidateFormat(eventsFormat); err != nil {
+		return err
+	}
+
+	command := cmd.Name()
+	if !cmd.HasParent() {
+		command = "status"
+	}
+
+	events.NewStream(os.Stdout, command).Attach()
+	output.SetStdout(os.Stderr)
+	hooks.SetOutput(os.Stderr)
+	return nil
+}
+
+func flagOverrides(cmd *cobra.Command) config.FlagOverrides {
+	var overrides config.FlagOverrides
+	flags := cmd.Flags()
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: $HOME/.config/gogws/config.yaml)")
+	if flags.Changed("parallel") {
+		overrides.Parallel = &parallel
+	}
+	if flags.Changed("format") {
+		overrides.Format = &format
+	}
+	if flags.Changed("theme") {
+		overrides.Theme = &themeFile
+	}
+	if flags.Changed("trust-hooks") {
+		overrides.TrustHooks = &trustHooks
+	}
+	if flags.Changed("no-color") {
+		overrides.NoColor = &noColor
+	}
+	if flags.Changed("only-changes") {
+		overrides.OnlyChanges = &onlyChanges
+	}
+	if flags.Changed("stop-on-error") {
+		overrides.StopOnError = &stopOnError
+	}
+
+	return overrides
+}
+
+func NewCommand() *cobra.Command {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "user config file (default: $HOME/.gws/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&themeFile, "theme", "", "theme file")
 	rootCmd.PersistentFlags().IntVar(&parallel, "parallel", 0, "number of parallel operations (default: 5)")
-	rootCmd.PersistentFlags().StringVar(&format, "format", "text", "output format (text, json, yaml)")
+	rootCmd.PersistentFlags().StringVar(&format, "format", "text", "output format (text, json, yaml, csv, markdown, junit, template=<go-template>)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
 	rootCmd.PersistentFlags().BoolVar(&onlyChanges, "only-changes", false, "show only repositories with changes")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
 	rootCmd.PersistentFlags().StringVar(&trustHooks, "trust-hooks", "ask", "trust mode for local hooks: ask, all, skip")
 	rootCmd.PersistentFlags().BoolVar(&stopOnError, "stop-on-error", false, "stop execution on first error")
+	rootCmd.PersistentFlags().StringVar(&eventsFormat, "events", "", "stream execution events to stdout (ndjson); regular output moves to stderr")
 
-	viper.BindPFlag("theme", rootCmd.PersistentFlags().Lookup("theme"))
-	viper.BindPFlag("parallel", rootCmd.PersistentFlags().Lookup("parallel"))
-	viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
-	viper.BindPFlag("no_color", rootCmd.PersistentFlags().Lookup("no-color"))
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exitcode.New(exitcode.Usage, err)
+	})
 
 	return rootCmd
 }
@@ -79,28 +162,3 @@ func NewCommand() *cobra.Command {
 func GetConfig() *config.Config {
 	return config.GetConfig()
 }
-
-func initConfig() {
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
-	} else {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		viper.AddConfigPath(home + "/.config/gogws")
-		viper.SetConfigName("config")
-		viper.SetConfigType("yaml")
-	}
-
-	viper.SetEnvPrefix("GOGWS")
-	viper.AutomaticEnv()
-
-	if err := viper.ReadInConfig(); err == nil {
-		if viper.GetBool("verbose") {
-			fmt.Println("Using config file:", viper.ConfigFileUsed())
-		}
-	}
-}
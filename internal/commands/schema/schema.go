@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"gogws/internal/export"
+	"gogws/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [command]",
+		Short: "Print the JSON schema of a command's machine-readable output",
+		Long: `Print the JSON schema (draft 2020-12) that describes the output of a command
+run with --format json or --format yaml.
+
+Every document carries a "schema" field such as "gogws/fetch/` + export.SchemaVersion + `".
+Without an argument, lists the commands that have a schema.`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: export.SchemaCommands(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				for _, command := range export.SchemaCommands() {
+					fmt.Fprintf(output.Stdout(), "%-8s %s\n", command, export.SchemaID(command))
+				}
+				return nil
+			}
+
+			schema, err := export.Schema(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(output.Stdout(), strings.TrimSpace(schema))
+			return nil
+		},
+	}
+}
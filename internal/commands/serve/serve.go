@@ -0,0 +1,121 @@
+package serve
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/hooks"
+	"gogws/internal/output"
+	"gogws/internal/server"
+	"gogws/internal/ui/cli"
+
+	"github.com/spf13/cobra"
+)
+
+const tokenEnv = "GOGWS_SERVE_TOKEN"
+
+var (
+	listen string
+	token  string
+	noAuth bool
+)
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve workspace state and actions over a local HTTP API",
+		Long: `Start a local HTTP server exposing the workspace as JSON.
+
+Endpoints (under /api/v1):
+  GET  /workspace        workspace tree (projects and nested workspaces)
+  GET  /status           same document as 'gogws status --format json'
+  GET  /status/<path>    status of a single project
+  POST /fetch, /ff, /update
+                         queue a job and return it with its id (202)
+  GET  /jobs, /jobs/<id> job state; finished jobs include the command's
+                         --format json result and exit code
+  GET  /events           Server-Sent Events stream of engine, hook and job events
+
+Requests must carry "Authorization: Bearer <token>" (or ?token=<token>).
+The token is taken from --token or $` + tokenEnv + `, or generated and printed at
+startup. Jobs run one at a time; hooks that would prompt for trust are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(getConfig)
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", "127.0.0.1:7777", "address to listen on")
+	cmd.Flags().StringVar(&token, "token", "", "bearer token required on every request (default: $"+tokenEnv+" or a generated one)")
+	cmd.Flags().BoolVar(&noAuth, "no-auth", false, "disable token authentication")
+
+	return cmd
+}
+
+func runServe(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running serve command", "workspace", cfg.WorkspaceRoot, "listen", listen)
+
+	secret := token
+	if secret == "" {
+		secret = os.Getenv(tokenEnv)
+	}
+	if noAuth {
+		secret = ""
+	} else if secret == "" {
+		var err error
+		if secret, err = generateToken(); err != nil {
+			return err
+		}
+	}
+
+	if hooks.GetTrustMode() == hooks.TrustModeAsk {
+		hooks.SetTrustMode(hooks.TrustModeSkip)
+	}
+	hooks.SetOutput(os.Stderr)
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listen, err)
+	}
+
+	renderer := cli.NewRenderer()
+	fmt.Fprintln(output.Stdout(), renderer.RenderInfo(fmt.Sprintf("Serving %s on http://%s%s", cfg.WorkspaceRoot, listener.Addr(), server.APIPrefix)))
+	if secret != "" {
+		fmt.Fprintln(output.Stdout(), renderer.RenderInfo("Token: "+secret))
+	} else {
+		fmt.Fprintln(output.Stdout(), renderer.RenderWarning("Authentication is disabled"))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(server.Options{
+		WorkspaceRoot: cfg.WorkspaceRoot,
+		Token:         secret,
+		Parallel:      cfg.Parallel,
+		StopOnError:   cfg.StopOnError,
+	})
+
+	return srv.Serve(ctx, listener)
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
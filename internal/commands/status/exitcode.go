@@ -0,0 +1,72 @@
+package status
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gogws/internal/git"
+)
+
+var (
+	exitConditions      = []string{"dirty", "ahead", "behind", "missing", "error"}
+	availableConditions = append(slices.Clone(exitConditions), "off-default")
+)
+
+func parseConditions(values []string) (map[string]bool, error) {
+	conditions := make(map[string]bool, len(values))
+	for _, value := range values {
+		name := strings.TrimSpace(strings.ToLower(value))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(availableConditions, name) {
+			return nil, fmt.Errorf("unknown exit condition %q (available: %s)", value, strings.Join(availableConditions, ", "))
+		}
+		conditions[name] = true
+	}
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("no exit conditions given (available: %s)", strings.Join(availableConditions, ", "))
+	}
+	return conditions, nil
+}
+
+func matchedConditions(status git.RepositoryStatus) []string {
+	if status.Error != nil {
+		return []string{"error"}
+	}
+	if !status.Exists {
+		return []string{"missing"}
+	}
+
+	var matched []string
+	if !status.Clean {
+		matched = append(matched, "dirty")
+	}
+	ahead, behind := status.Ahead > 0, status.Behind > 0
+	for _, b := range status.Branches {
+		ahead = ahead || b.Ahead > 0
+		behind = behind || b.Behind > 0
+	}
+	if ahead {
+		matched = append(matched, "ahead")
+	}
+	if behind {
+		matched = append(matched, "behind")
+	}
+	if !status.OnDefaultBranch() {
+		matched = append(matched, "off-default")
+	}
+	return matched
+}
+
+func shouldFail(statuses []git.RepositoryStatus, conditions map[string]bool) bool {
+	for _, status := range statuses {
+		for _, c := range matchedConditions(status) {
+			if conditions[c] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"gogws/internal/actions"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+func TestShouldFail(t *testing.T) {
+	clean := git.RepositoryStatus{Path: "clean", Exists: true, Clean: true}
+	dirty := git.RepositoryStatus{Path: "dirty", Exists: true, Clean: false, Uncommitted: 2}
+	behind := git.RepositoryStatus{Path: "behind", Exists: true, Clean: true, Branches: []git.BranchStatus{{Name: "main", Behind: 3}}}
+	offDefault := git.RepositoryStatus{Path: "feature", Exists: true, Clean: true, Branch: "feature", DefaultBranch: "main"}
+	missing := git.RepositoryStatus{Path: "missing"}
+	broken := git.RepositoryStatus{Path: "broken", Error: errors.New("not a git repository")}
+
+	tests := []struct {
+		name       string
+		statuses   []git.RepositoryStatus
+		conditions []string
+		want       bool
+	}{
+		{"all clean", []git.RepositoryStatus{clean}, exitConditions, false},
+		{"dirty counts", []git.RepositoryStatus{clean, dirty}, exitConditions, true},
+		{"dirty ignored", []git.RepositoryStatus{dirty}, []string{"behind", "missing"}, false},
+		{"behind on other branch", []git.RepositoryStatus{behind}, []string{"behind"}, true},
+		{"off default not counted by default", []git.RepositoryStatus{offDefault}, exitConditions, false},
+		{"off default", []git.RepositoryStatus{offDefault}, []string{"off-default"}, true},
+		{"missing", []git.RepositoryStatus{missing}, []string{"missing"}, true},
+		{"missing ignored", []git.RepositoryStatus{missing}, []string{"dirty"}, false},
+		{"error", []git.RepositoryStatus{broken}, []string{"error"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := parseConditions(tt.conditions)
+			if err != nil {
+				t.Fatalf("parseConditions() error = %v", err)
+			}
+			if got := shouldFail(tt.statuses, conditions); got != tt.want {
+				t.Errorf("shouldFail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConditionsRejectsUnknown(t *testing.T) {
+	if _, err := parseConditions([]string{"dirty", "stale"}); err == nil {
+		t.Error("expected error for unknown condition")
+	}
+}
+
+func TestShouldFailOnGitError(t *testing.T) {
+	root := t.TempDir()
+	if err := exec.Command("git", "init", "-q", filepath.Join(root, "unborn")).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	statuses := actions.Status(root, []gws.Project{{Path: "unborn"}}, 1, nil)
+	if len(statuses) != 1 || statuses[0].Error == nil {
+		t.Fatalf("expected an error status for a repository without commits, got %+v", statuses)
+	}
+
+	conditions, err := parseConditions([]string{"error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shouldFail(statuses, conditions) {
+		t.Error("error condition should match a repository whose status failed")
+	}
+}
@@ -1,39 +1,70 @@
 package status
 
 import (
-	"encoding/json"
 	"fmt"
 	"log/slog"
-	"path/filepath"
-	"sync"
+	"strings"
 
+	"gogws/internal/actions"
+	"gogws/internal/cache"
 	"gogws/internal/config"
-	"gogws/internal/engine"
+	"gogws/internal/exitcode"
 	"gogws/internal/export"
 	"gogws/internal/git"
 	"gogws/internal/gws"
-	"gogws/internal/ui/cli"
+	"gogws/internal/log"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	columns  string
+	exitCode bool
+	exitOn   []string
+	noCache  bool
+)
+
 func NewCommand(getConfig func() *config.Config) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "status",
 		Aliases: []string{"st"},
 		Short:   "Show the status of all repositories in the workspace",
 		Long: `Display the status of all repositories defined in .projects.gws file.
-Shows uncommitted changes, untracked files, and sync status with remotes.`,
+Shows uncommitted changes, untracked files, and sync status with remotes.
+Repositories not checked out on their default branch are marked, together
+with how far they are behind it.
+
+Output can be shaped for other tools:
+  --columns path,branch,ahead,behind,dirty,last-commit   aligned table
+  --format 'template={{.Path}}\t{{.Branch}}\t{{.Behind}}' one line per repository
+
+Results are cached in .gws/cache and reused while a repository's index,
+refs and working tree are unchanged. Use --no-cache to recompute everything;
+with --verbose, rows served from the cache are marked "(cached)".
+
+With --exit-code, the status is printed as usual and the command exits
+with code 4 when any repository matches one of the --exit-on conditions.
+
+Available columns: ` + strings.Join(export.AvailableColumns, ", ") + `
+Available exit conditions: ` + strings.Join(availableConditions, ", "),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatus(getConfig)
+			return runStatus(getConfig, exitCode || cmd.Flags().Changed("exit-on"))
 		},
 	}
+
+	cmd.Flags().StringVar(&columns, "columns", "", "render an aligned table with the given comma-separated columns")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "exit with code 4 when any repository matches an --exit-on condition")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "ignore and do not update the status cache")
+	cmd.Flags().StringSliceVar(&exitOn, "exit-on", exitConditions, "conditions that count for --exit-code (implies --exit-code)")
+
+	return cmd
 }
 
-func runStatus(getConfig func() *config.Config) error {
+func runStatus(getConfig func() *config.Config, checkExit bool) error {
 	cfg := getConfig()
 	if cfg == nil {
-		return fmt.Errorf("no workspace found (no .projects.gws file)")
+		return exitcode.ErrNoWorkspace
 	}
 
 	slog.Debug("Running status command", "workspace", cfg.WorkspaceRoot)
@@ -49,81 +80,91 @@ func runStatus(getConfig func() *config.Config) error {
 
 	slog.Debug("Found projects and workspaces", "projects", len(ws.Projects), "workspaces", len(ws.Children))
 
-	statuses := getStatuses(cfg.WorkspaceRoot, ws.Projects, cfg.Parallel)
-
-	if cfg.Format == "json" || cfg.Format == "yaml" {
-		output, err := export.Format(statuses, cfg.Format)
+	var selected []string
+	if columns != "" {
+		selected, err = export.ParseColumns(columns)
 		if err != nil {
-			return fmt.Errorf("failed to export status: %w", err)
+			return exitcode.New(exitcode.Usage, err)
+		}
+	}
+
+	var conditions map[string]bool
+	if checkExit {
+		if conditions, err = parseConditions(exitOn); err != nil {
+			return exitcode.New(exitcode.Usage, err)
+		}
+	}
+
+	out, err := output.New(cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	var c *cache.Cache
+	if !noCache {
+		c = cache.Load(cfg.WorkspaceRoot)
+	}
+
+	statuses := actions.Status(cfg.WorkspaceRoot, ws.Projects, cfg.Parallel, c)
+	if c != nil {
+		if err := c.Save(); err != nil {
+			slog.Warn("Failed to save status cache", "err", err)
 		}
-		fmt.Println(output)
-		return nil
 	}
 
-	renderer := cli.NewRenderer()
-	output := renderer.RenderStatus(statuses, ws, ws.Children, cfg.OnlyChanges)
-	fmt.Println(output)
+	if err := render(out, cfg, ws, statuses, selected); err != nil {
+		return err
+	}
+
+	if conditions != nil && shouldFail(statuses, conditions) {
+		return exitcode.Silent(exitcode.Changes)
+	}
 
 	return nil
 }
 
-func getStatuses(workspaceRoot string, projects []gws.Project, parallel int) []git.RepositoryStatus {
-	if len(projects) == 0 {
+func render(out *output.Writer, cfg *config.Config, ws *gws.Workspace, statuses []git.RepositoryStatus, selected []string) error {
+	isTemplate := strings.HasPrefix(cfg.Format, export.TemplatePrefix)
+	if cfg.OnlyChanges && (isTemplate || selected != nil) {
+		statuses = onlyChanged(statuses)
+	}
+
+	if !out.IsText() {
+		return out.Status(statuses)
+	}
+
+	renderer := out.Renderer()
+	renderer.SetShowCached(log.IsVerbose())
+
+	if selected != nil {
+		fmt.Fprintln(output.Stdout(), renderer.RenderColumns(selected, export.ToColumns(statuses, selected)))
 		return nil
 	}
 
-	var mu sync.Mutex
-	statusMap := make(map[string]git.RepositoryStatus)
-
-	commands := make([]engine.RepoCommand, 0, len(projects))
-
-	for _, p := range projects {
-		repoPath := filepath.Join(workspaceRoot, p.Path)
-		projectPath := p.Path
-
-		cmd := engine.NewCustomCommand(
-			repoPath,
-			projectPath,
-			func() (string, error) {
-				status := git.GetStatus(repoPath)
-				status.Path = projectPath
-
-				data, err := json.Marshal(status)
-				if err != nil {
-					return "", err
-				}
-				return string(data), nil
-			},
-		)
-		commands = append(commands, cmd)
-	}
-
-	result := engine.Execute(commands, engine.ExecuteOptions{
-		Parallel: parallel,
-		OnComplete: func(r engine.Result) {
-			if r.Success && r.Stdout != "" {
-				var status git.RepositoryStatus
-				if err := json.Unmarshal([]byte(r.Stdout), &status); err == nil {
-					mu.Lock()
-					statusMap[r.Command.RepoName] = status
-					mu.Unlock()
-				}
-			}
-		},
-	})
-
-	statuses := make([]git.RepositoryStatus, 0, len(projects))
-	for _, r := range result.Results {
-		if status, ok := statusMap[r.Command.RepoName]; ok {
-			statuses = append(statuses, status)
-		} else {
-			statuses = append(statuses, git.RepositoryStatus{
-				Path:   r.Command.RepoName,
-				Exists: false,
-				Error:  r.Error,
-			})
+	fmt.Fprintln(output.Stdout(), renderer.RenderStatus(statuses, ws, ws.Children, cfg.OnlyChanges))
+
+	return nil
+}
+
+func onlyChanged(statuses []git.RepositoryStatus) []git.RepositoryStatus {
+	changed := make([]git.RepositoryStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if !status.Exists {
+			continue
 		}
+		if status.Error == nil && status.Clean && !hasBranchChanges(status.Branches) {
+			continue
+		}
+		changed = append(changed, status)
 	}
+	return changed
+}
 
-	return statuses
+func hasBranchChanges(branches []git.BranchStatus) bool {
+	for _, b := range branches {
+		if b.Ahead > 0 || b.Behind > 0 {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,69 @@
+package uicmd
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gogws/internal/config"
+	"gogws/internal/exitcode"
+	"gogws/internal/gws"
+	"gogws/internal/ui/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var refreshInterval time.Duration
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ui",
+		Short: "Interactive dashboard for the workspace",
+		Long: `Open a full-screen dashboard showing the workspace tree with live status.
+
+Keys:
+  ↑/↓, j/k   move               space   select repository
+  /          fuzzy search       a       select all visible
+  tab        filter all/changed/missing
+  f          fetch              p       fast-forward pull
+  P          push               s       stash
+  o, enter   open a shell in the repository
+  r          refresh now        q       quit
+
+Actions run on the selected repositories, or on the one under the cursor
+when nothing is selected. Their output streams into the side pane.
+Hooks are not run from the dashboard.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUI(getConfig)
+		},
+	}
+
+	cmd.Flags().DurationVar(&refreshInterval, "refresh", 5*time.Second, "interval between background status refreshes")
+
+	return cmd
+}
+
+func runUI(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	slog.Debug("Running ui command", "workspace", cfg.WorkspaceRoot)
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Load()
+	if err != nil {
+		return fmt.Errorf("failed to resolve workspace: %w", err)
+	}
+
+	model := tui.New(ws, tui.Options{
+		Parallel:        cfg.Parallel,
+		RefreshInterval: refreshInterval,
+	})
+
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("ui failed: %w", err)
+	}
+	return nil
+}
@@ -3,14 +3,14 @@ package update
 import (
 	"fmt"
 	"log/slog"
-	"path/filepath"
 
+	"gogws/internal/actions"
 	"gogws/internal/config"
 	"gogws/internal/engine"
-	"gogws/internal/git"
+	"gogws/internal/exitcode"
 	"gogws/internal/gws"
 	"gogws/internal/hooks"
-	"gogws/internal/ui/cli"
+	"gogws/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -43,11 +43,7 @@ Use --skip-workspaces to only clone projects.`,
 func runUpdate(getConfig func() *config.Config) error {
 	cfg := getConfig()
 	if cfg == nil {
-		return fmt.Errorf("no workspace found (no .projects.gws file)")
-	}
-
-	if err := hooks.PreUpdate(cfg.WorkspaceRoot); err != nil {
-		return fmt.Errorf("pre-update hook failed: %w", err)
+		return exitcode.ErrNoWorkspace
 	}
 
 	slog.Debug(fmt.Sprintf("Running update command in workspace: %s", cfg.WorkspaceRoot))
@@ -56,24 +52,55 @@ func runUpdate(getConfig func() *config.Config) error {
 		return fmt.Errorf("failed to resolve workspace: %w", err)
 	}
 
-	renderer := cli.NewRenderer()
-	output := engine.NewOutputHandler(renderer, false)
+	var pending []string
+	if !skipWorkspaces {
+		for _, child := range ws.MissingWorkspaces() {
+			pending = append(pending, child.Path)
+		}
+	}
+	if !skipProjects {
+		for _, p := range ws.MissingProjects() {
+			pending = append(pending, p.Path)
+		}
+	}
+
+	out, err := output.New(cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hooks.PreUpdate(cfg.WorkspaceRoot, pending)
+	if err != nil {
+		return fmt.Errorf("pre-update hook failed: %w", err)
+	}
+
 	var clonedProjects []string
+	combined := engine.NewExecuteResult()
+	opts := actions.Options{Parallel: cfg.Parallel, StopOnError: cfg.StopOnError}
+
+	stopProgress := out.Progress()
+	defer stopProgress()
 
 	if !skipWorkspaces && len(ws.Children) > 0 {
-		result := cloneWorkspaces(cfg.WorkspaceRoot, ws, cfg.Parallel, cfg.StopOnError)
-		output.RenderSummary(result, "Cloned workspaces")
+		result := actions.CloneWorkspaces(cfg.WorkspaceRoot, ws.MissingWorkspaces(), resp, opts)
+		if out.IsText() {
+			out.Results(result, "update", "Cloned workspaces")
+		}
+		actions.Merge(combined, result)
 	}
 
 	if !skipProjects {
 		missingProjects := ws.MissingProjects()
 		if len(missingProjects) == 0 {
-			fmt.Println(renderer.RenderSuccess("All projects are already cloned"))
+			out.Success("All projects are already cloned")
 		} else {
-			fmt.Println(renderer.RenderInfo(fmt.Sprintf("Cloning %d missing projects...", len(missingProjects))))
+			out.Info(fmt.Sprintf("Cloning %d missing projects...", len(missingProjects)))
 
-			result := cloneProjects(cfg.WorkspaceRoot, missingProjects, cfg.Parallel, cfg.StopOnError)
-			output.RenderSummary(result, "Cloned projects")
+			result := actions.CloneProjects(cfg.WorkspaceRoot, missingProjects, resp, opts)
+			if out.IsText() {
+				out.Results(result, "update", "Cloned projects")
+			}
+			actions.Merge(combined, result)
 
 			for _, r := range result.Succeeded() {
 				clonedProjects = append(clonedProjects, r.Command.RepoName)
@@ -81,70 +108,15 @@ func runUpdate(getConfig func() *config.Config) error {
 		}
 	}
 
-	if err := hooks.PostUpdate(cfg.WorkspaceRoot, clonedProjects); err != nil {
-		return fmt.Errorf("post-update hook failed: %w", err)
-	}
-
-	return nil
-}
-
-func cloneWorkspaces(workspaceRoot string, ws *gws.Workspace, parallel int, stopOnError bool) *engine.ExecuteResult {
-	toClone := ws.MissingWorkspaces()
-	if len(toClone) == 0 {
-		return engine.NewExecuteResult()
-	}
-
-	commands := make([]engine.RepoCommand, 0, len(toClone))
-
-	for _, child := range toClone {
-		remotes := []git.Remote{{Name: child.Remote.Name, URL: child.Remote.URL}}
-		wsRoot := workspaceRoot
-		childPath := child.Path
-
-		cmd := engine.NewCustomCommand(
-			filepath.Join(workspaceRoot, child.Path),
-			child.Path,
-			func() (string, error) {
-				return "", git.CloneWorkspace(wsRoot, childPath, remotes)
-			},
-		)
-		commands = append(commands, cmd)
+	if !out.IsText() {
+		if err := out.Results(combined, "update", ""); err != nil {
+			return err
+		}
 	}
 
-	return engine.Execute(commands, engine.ExecuteOptions{
-		Parallel:    parallel,
-		StopOnError: stopOnError,
-	})
-}
-
-func cloneProjects(workspaceRoot string, toClone []gws.Project, parallel int, stopOnError bool) *engine.ExecuteResult {
-	commands := make([]engine.RepoCommand, 0, len(toClone))
-
-	for _, p := range toClone {
-		remotes := toGitRemotes(p.Remotes)
-		wsRoot := workspaceRoot
-		projectPath := p.Path
-
-		cmd := engine.NewCustomCommand(
-			filepath.Join(workspaceRoot, p.Path),
-			p.Path,
-			func() (string, error) {
-				return "", git.CloneWorkspace(wsRoot, projectPath, remotes)
-			},
-		)
-		commands = append(commands, cmd)
+	if err := hooks.PostUpdate(cfg.WorkspaceRoot, clonedProjects); err != nil {
+		return fmt.Errorf("post-update hook failed: %w", err)
 	}
 
-	return engine.Execute(commands, engine.ExecuteOptions{
-		Parallel:    parallel,
-		StopOnError: stopOnError,
-	})
-}
-
-func toGitRemotes(remotes []gws.Remote) []git.Remote {
-	result := make([]git.Remote, len(remotes))
-	for i, r := range remotes {
-		result[i] = git.Remote{Name: r.Name, URL: r.URL}
-	}
-	return result
+	return exitcode.FromResult(combined, "update")
 }
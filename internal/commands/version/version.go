@@ -3,6 +3,8 @@ package version
 import (
 	"fmt"
 
+	"gogws/internal/output"
+
 	"github.com/spf13/cobra"
 )
 
@@ -14,7 +16,7 @@ func NewCommand() *cobra.Command {
 		Short:   "Print the version number of gogws",
 		Aliases: []string{"v"},
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("gogws version %s\n", Version)
+			fmt.Fprintf(output.Stdout(), "gogws version %s\n", Version)
 		},
 	}
 }
@@ -0,0 +1,264 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"gogws/internal/config"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/output"
+	"gogws/internal/ui/cli"
+	fswatch "gogws/internal/watch"
+
+	"github.com/spf13/cobra"
+)
+
+var fetchInterval time.Duration
+
+func NewCommand(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Keep the workspace status up to date as files change",
+		Long: `Print the status of all repositories and keep it up to date.
+
+Each repository's .git/HEAD, index, refs and working tree are watched, and
+status is recomputed only for repositories that changed. With
+--fetch-interval, all repositories are fetched periodically in the background.
+
+With --format json, one JSON object is printed per line: a "status" event for
+every repository at start and whenever its status changes, and a "fetch" event
+after each background fetch. Use it to feed tmux or status bars.
+
+Stop with Ctrl+C.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(getConfig)
+		},
+	}
+
+	cmd.Flags().DurationVar(&fetchInterval, "fetch-interval", 0, "fetch all repositories at this interval (0 disables)")
+
+	return cmd
+}
+
+type watcher struct {
+	cfg      *config.Config
+	ws       *gws.Workspace
+	headless bool
+	redraw   bool
+	order    []string
+	dirs     map[string]string
+	defaults map[string]string
+	statuses map[string]git.RepositoryStatus
+	last     map[string]string
+}
+
+func runWatch(getConfig func() *config.Config) error {
+	cfg := getConfig()
+	if cfg == nil {
+		return exitcode.ErrNoWorkspace
+	}
+
+	headless := cfg.Format == "json"
+	if !headless && !export.IsText(cfg.Format) {
+		return exitcode.New(exitcode.Usage, fmt.Errorf("format %s is not supported for watch (supported: text, json)", cfg.Format))
+	}
+
+	slog.Debug("Running watch command", "workspace", cfg.WorkspaceRoot)
+
+	ws, err := gws.New(cfg.WorkspaceRoot).Load()
+	if err != nil {
+		return fmt.Errorf("failed to resolve workspace: %w", err)
+	}
+	if len(ws.Projects) == 0 {
+		return fmt.Errorf("no projects found")
+	}
+
+	w := &watcher{
+		cfg:      cfg,
+		ws:       ws,
+		headless: headless,
+		redraw:   !headless && output.IsTerminal(),
+		dirs:     make(map[string]string),
+		defaults: make(map[string]string),
+		statuses: make(map[string]git.RepositoryStatus),
+		last:     make(map[string]string),
+	}
+
+	var repos []fswatch.Repo
+	for _, p := range ws.Projects {
+		dir := filepath.Join(cfg.WorkspaceRoot, p.Path)
+		w.order = append(w.order, p.Path)
+		w.dirs[p.Path] = dir
+		w.defaults[p.Path] = p.DefaultBranch
+		repos = append(repos, fswatch.Repo{Name: p.Path, Dir: dir})
+	}
+
+	fsw, err := fswatch.New(repos, fswatch.DefaultDebounce)
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer fsw.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go fsw.Run(ctx)
+
+	w.refresh(w.order)
+
+	var fetchTick <-chan time.Time
+	if fetchInterval > 0 {
+		ticker := time.NewTicker(fetchInterval)
+		defer ticker.Stop()
+		fetchTick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case name := <-fsw.Changes():
+			w.refresh([]string{name})
+		case <-fetchTick:
+			w.fetch()
+		}
+	}
+}
+
+func (w *watcher) refresh(names []string) {
+	var mu sync.Mutex
+	fresh := make(map[string]git.RepositoryStatus, len(names))
+
+	commands := make([]engine.RepoCommand, 0, len(names))
+	for _, name := range names {
+		dir, path, defaultBranch := w.dirs[name], name, w.defaults[name]
+		commands = append(commands, engine.NewCustomCommand(dir, path, func() (string, error) {
+			status := git.GetStatusWithDefault(dir, defaultBranch)
+			status.Path = path
+
+			mu.Lock()
+			fresh[path] = status
+			mu.Unlock()
+			return "", nil
+		}))
+	}
+
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: w.cfg.Parallel})
+
+	var changed []string
+	for _, r := range result.Results {
+		status, ok := fresh[r.Command.RepoName]
+		if !ok {
+			status = git.RepositoryStatus{Path: r.Command.RepoName, Error: r.Error}
+		}
+
+		key := fingerprint(status)
+		if w.last[status.Path] == key {
+			continue
+		}
+		w.last[status.Path] = key
+		w.statuses[status.Path] = status
+		changed = append(changed, status.Path)
+	}
+
+	if len(changed) > 0 {
+		w.render(changed)
+	}
+}
+
+func (w *watcher) fetch() {
+	var commands []engine.RepoCommand
+	var names []string
+	for _, name := range w.order {
+		if status, ok := w.statuses[name]; ok && status.Exists {
+			commands = append(commands, engine.NewGitCommand(w.dirs[name], name, "fetch", "--all"))
+			names = append(names, name)
+		}
+	}
+
+	slog.Debug("Background fetch", "repos", len(commands))
+	result := engine.Execute(commands, engine.ExecuteOptions{Parallel: w.cfg.Parallel})
+
+	if w.headless {
+		if line, err := export.WatchFetchLine(result, time.Now()); err == nil {
+			fmt.Fprintln(output.Stdout(), line)
+		}
+	} else if !w.redraw {
+		fmt.Fprintf(output.Stdout(), "%s fetched %d repositories, %d failed\n", time.Now().Format(time.TimeOnly), result.SuccessCount(), result.FailedCount())
+	}
+
+	w.refresh(names)
+}
+
+func (w *watcher) render(changed []string) {
+	now := time.Now()
+
+	switch {
+	case w.headless:
+		for _, name := range changed {
+			if line, err := export.WatchStatusLine(w.statuses[name], now); err == nil {
+				fmt.Fprintln(output.Stdout(), line)
+			}
+		}
+
+	case w.redraw:
+		statuses := make([]git.RepositoryStatus, 0, len(w.order))
+		for _, name := range w.order {
+			statuses = append(statuses, w.statuses[name])
+		}
+		renderer := cli.NewRenderer()
+		fmt.Fprint(output.Stdout(), "\x1b[H\x1b[2J")
+		fmt.Fprintln(output.Stdout(), renderer.RenderStatus(statuses, w.ws, w.ws.Children, w.cfg.OnlyChanges))
+		fmt.Fprintln(output.Stdout(), renderer.RenderInfo(fmt.Sprintf("Watching %d repositories · updated %s · Ctrl+C to stop", len(w.order), now.Format(time.TimeOnly))))
+
+	default:
+		for _, name := range changed {
+			fmt.Fprintf(output.Stdout(), "%s %s\n", now.Format(time.TimeOnly), describe(w.statuses[name]))
+		}
+	}
+}
+
+func fingerprint(status git.RepositoryStatus) string {
+	data, _ := json.Marshal(export.ToRepositoryOutput(status))
+	return string(data)
+}
+
+func describe(status git.RepositoryStatus) string {
+	switch {
+	case status.Error != nil:
+		return fmt.Sprintf("%s: error: %v", status.Path, status.Error)
+	case !status.Exists:
+		return fmt.Sprintf("%s: missing", status.Path)
+	}
+
+	parts := []string{status.Path + ":", status.Branch}
+	if status.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", status.Ahead))
+	}
+	if status.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", status.Behind))
+	}
+	if status.Uncommitted > 0 {
+		parts = append(parts, fmt.Sprintf("%d uncommitted", status.Uncommitted))
+	}
+	if status.Untracked > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", status.Untracked))
+	}
+	if status.Clean && status.Ahead == 0 && status.Behind == 0 {
+		parts = append(parts, "clean")
+	}
+	return strings.Join(parts, " ")
+}
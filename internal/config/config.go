@@ -2,6 +2,7 @@ package config
 
 import (
 	"log/slog"
+	"path/filepath"
 	"sync"
 
 	"gogws/internal/gws"
@@ -17,15 +18,26 @@ type Config struct {
 	NoColor       bool
 	OnlyChanges   bool
 	StopOnError   bool
+	TrustHooks    string
 }
 
 var (
-	globalConfig *Config
-	configMu     sync.RWMutex
-	initialized  bool
+	globalConfig   *Config
+	globalResolved *Resolved
+	globalFlags    FlagOverrides
+	configMu       sync.RWMutex
+	initialized    bool
 )
 
-func Initialize() error {
+func Initialize(flags FlagOverrides) error {
+	return initialize(flags, false)
+}
+
+func InitializeSkippingInvalid(flags FlagOverrides) error {
+	return initialize(flags, true)
+}
+
+func initialize(flags FlagOverrides, skipInvalid bool) error {
 	configMu.Lock()
 	defer configMu.Unlock()
 
@@ -34,14 +46,29 @@ func Initialize() error {
 	}
 
 	slog.Debug("Initializing configuration manager...")
+	globalFlags = flags
 
-	cfg, err := load()
+	workspaceRoot := ""
+	if wsInfo, err := gws.FindRoot(); err == nil {
+		workspaceRoot = wsInfo.Root
+	} else {
+		slog.Debug("No workspace found", "err", err)
+	}
+
+	resolved, err := resolve(workspaceRoot, flags, skipInvalid)
 	if err != nil {
 		return err
 	}
+	globalResolved = resolved
+	initialized = true
+	gws.SetURLResolver(resolved.ResolveURL)
+
+	if workspaceRoot == "" {
+		return nil
+	}
 
+	cfg := build(workspaceRoot, resolved)
 	globalConfig = cfg
-	initialized = true
 
 	slog.Debug("Configuration loaded", "workspaceRoot", cfg.WorkspaceRoot, "parallel", cfg.Parallel)
 
@@ -54,6 +81,12 @@ func GetConfig() *Config {
 	return globalConfig
 }
 
+func GetResolved() *Resolved {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return globalResolved
+}
+
 func MustGetConfig() *Config {
 	cfg := GetConfig()
 	if cfg == nil {
@@ -71,45 +104,29 @@ func IsInitialized() bool {
 func Reload() error {
 	configMu.Lock()
 	initialized = false
+	globalConfig = nil
+	globalResolved = nil
+	flags := globalFlags
 	configMu.Unlock()
-	return Initialize()
+	return Initialize(flags)
 }
 
-func ApplyFlags(themeFile string, parallel int, format string, noColor, onlyChanges, stopOnError bool) {
-	configMu.Lock()
-	defer configMu.Unlock()
-
-	if globalConfig == nil {
-		return
+func build(workspaceRoot string, resolved *Resolved) *Config {
+	themeFile := resolved.Theme.Value
+	if themeFile != "" && resolved.Theme.Source == SourceWorkspace && !filepath.IsAbs(themeFile) {
+		themeFile = filepath.Join(workspaceRoot, themeFile)
 	}
 
-	if themeFile != "" {
-		globalConfig.ThemeFile = themeFile
+	return &Config{
+		WorkspaceRoot: workspaceRoot,
+		ProjectsFile:  gws.ProjectsFileName,
+		IgnoreFile:    gws.IgnoreFileName,
+		ThemeFile:     themeFile,
+		Parallel:      resolved.Parallel.Value,
+		Format:        resolved.Format.Value,
+		NoColor:       resolved.NoColor.Value,
+		OnlyChanges:   resolved.OnlyChanges.Value,
+		StopOnError:   resolved.StopOnError.Value,
+		TrustHooks:    resolved.TrustHooks.Value,
 	}
-	if parallel > 0 {
-		globalConfig.Parallel = parallel
-	}
-	if format != "" {
-		globalConfig.Format = format
-	}
-	globalConfig.NoColor = noColor
-	globalConfig.OnlyChanges = onlyChanges
-	globalConfig.StopOnError = stopOnError
-}
-
-func load() (*Config, error) {
-	cfg := &Config{
-		ProjectsFile: gws.ProjectsFileName,
-		IgnoreFile:   gws.IgnoreFileName,
-		Parallel:     gws.DefaultParallel,
-		Format:       "text",
-	}
-
-	wsInfo, err := gws.FindRoot()
-	if err != nil {
-		return nil, err
-	}
-	cfg.WorkspaceRoot = wsInfo.Root
-
-	return cfg, nil
 }
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Scope string
+
+const (
+	ScopeUser      Scope = "user"
+	ScopeWorkspace Scope = "workspace"
+)
+
+type Key struct {
+	Name        string
+	Type        string
+	Description string
+	UserOnly    bool
+}
+
+var keys = []Key{
+	{Name: "parallel", Type: "number", Description: "Number of parallel operations"},
+	{Name: "format", Type: "string", Description: "Output format (text, json, yaml, csv, markdown, junit, template=<go-template>)"},
+	{Name: "theme", Type: "path", Description: "Theme file for colored output"},
+	{Name: "trust-hooks", Type: "ask|all|skip", Description: "Trust mode for local hooks"},
+	{Name: "no-color", Type: "bool", Description: "Disable colored output"},
+	{Name: "only-changes", Type: "bool", Description: "Show only repositories with changes"},
+	{Name: "stop-on-error", Type: "bool", Description: "Stop execution on first error"},
+	{Name: "trusted-workspaces", Type: "list of paths", Description: "Workspace paths where local hooks are trusted", UserOnly: true},
+}
+
+func GetAvailableKeys() []Key {
+	return keys
+}
+
+func GetAvailableConfigKeys() []string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.Name
+	}
+	return names
+}
+
+func LookupKey(name string) (Key, bool) {
+	for _, k := range keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+func GetEnvVarName(key string) string {
+	k, ok := LookupKey(key)
+	if !ok || k.UserOnly {
+		return ""
+	}
+	return "GOGWS_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+func unknownKeyError(key string) error {
+	return fmt.Errorf("unknown configuration key: %s\n\nAvailable keys:\n  %s",
+		key, strings.Join(GetAvailableConfigKeys(), "\n  "))
+}
+
+const AliasKeyPrefix = "alias."
+
+func (r *Resolved) Lookup(key string) (value any, origin string, err error) {
+	if name, ok := strings.CutPrefix(key, AliasKeyPrefix); ok {
+		alias, found := r.Aliases[name]
+		if !found {
+			return nil, "", fmt.Errorf("alias not defined: %s", name)
+		}
+		return alias.Value.String(), alias.Origin(), nil
+	}
+	if name, ok := strings.CutPrefix(key, VarKeyPrefix); ok {
+		v, found := r.LookupVar(name)
+		if !found {
+			return nil, "", fmt.Errorf("variable not defined: %s", name)
+		}
+		return v.Value, v.Origin(), nil
+	}
+
+	switch key {
+	case "parallel":
+		return r.Parallel.Value, r.Parallel.Origin(), nil
+	case "format":
+		return r.Format.Value, r.Format.Origin(), nil
+	case "theme":
+		return r.Theme.Value, r.Theme.Origin(), nil
+	case "trust-hooks":
+		return r.TrustHooks.Value, r.TrustHooks.Origin(), nil
+	case "no-color":
+		return r.NoColor.Value, r.NoColor.Origin(), nil
+	case "only-changes":
+		return r.OnlyChanges.Value, r.OnlyChanges.Origin(), nil
+	case "stop-on-error":
+		return r.StopOnError.Value, r.StopOnError.Origin(), nil
+	case "trusted-workspaces":
+		return r.TrustedWorkspaces.Value, r.TrustedWorkspaces.Origin(), nil
+	default:
+		return nil, "", unknownKeyError(key)
+	}
+}
+
+func SetValue(scope Scope, workspaceRoot, key, raw string) error {
+	k, ok := LookupKey(key)
+	if strings.HasPrefix(key, AliasKeyPrefix) && key != AliasKeyPrefix {
+		k, ok = Key{Name: key}, true
+	}
+	if name, isVar := strings.CutPrefix(key, VarKeyPrefix); isVar {
+		if err := validateVarName(name); err != nil {
+			return err
+		}
+		k, ok = Key{Name: key}, true
+	}
+	if !ok {
+		return unknownKeyError(key)
+	}
+	if k.UserOnly && scope != ScopeUser {
+		return fmt.Errorf("%s can only be set in the user configuration", key)
+	}
+	if key == "trust-hooks" && raw == "all" && scope != ScopeUser {
+		return fmt.Errorf("trust-hooks all can only be set in the user configuration")
+	}
+
+	var cfg *FileConfig
+	var err error
+	if scope == ScopeWorkspace {
+		if workspaceRoot == "" {
+			return fmt.Errorf("no workspace found for --workspace")
+		}
+		cfg, err = LoadWorkspaceConfig(workspaceRoot)
+	} else {
+		cfg, err = LoadUserConfig()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := applyValue(cfg, key, raw); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if scope == ScopeWorkspace {
+		return SaveWorkspaceConfig(workspaceRoot, cfg)
+	}
+	return SaveUserConfig(cfg)
+}
+
+func applyValue(cfg *FileConfig, key, raw string) error {
+	if name, ok := strings.CutPrefix(key, AliasKeyPrefix); ok {
+		if cfg.Aliases == nil {
+			cfg.Aliases = make(map[string]Alias)
+		}
+		cfg.Aliases[name] = Alias{raw}
+		return nil
+	}
+	if name, ok := strings.CutPrefix(key, VarKeyPrefix); ok {
+		if cfg.Vars == nil {
+			cfg.Vars = make(map[string]string)
+		}
+		cfg.Vars[name] = raw
+		return nil
+	}
+
+	switch key {
+	case "parallel":
+		n, err := parseInt(raw)
+		if err != nil {
+			return err
+		}
+		cfg.Parallel = &n
+	case "format":
+		cfg.Format = &raw
+	case "theme":
+		cfg.Theme = &raw
+	case "trust-hooks":
+		mode, err := parseTrustHooks(raw)
+		if err != nil {
+			return err
+		}
+		cfg.TrustHooks = &mode
+	case "no-color", "only-changes", "stop-on-error":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", raw)
+		}
+		switch key {
+		case "no-color":
+			cfg.NoColor = &b
+		case "only-changes":
+			cfg.OnlyChanges = &b
+		case "stop-on-error":
+			cfg.StopOnError = &b
+		}
+	case "trusted-workspaces":
+		for _, existing := range cfg.TrustedWorkspaces {
+			if existing == raw {
+				return nil
+			}
+		}
+		cfg.TrustedWorkspaces = append(cfg.TrustedWorkspaces, raw)
+	}
+	return nil
+}
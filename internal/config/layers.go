@@ -0,0 +1,200 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"gogws/internal/gws"
+)
+
+type FlagOverrides struct {
+	Parallel    *int
+	Format      *string
+	Theme       *string
+	TrustHooks  *string
+	NoColor     *bool
+	OnlyChanges *bool
+	StopOnError *bool
+}
+
+type Resolved struct {
+	Parallel          ConfigValue[int]
+	Format            ConfigValue[string]
+	Theme             ConfigValue[string]
+	TrustHooks        ConfigValue[string]
+	NoColor           ConfigValue[bool]
+	OnlyChanges       ConfigValue[bool]
+	StopOnError       ConfigValue[bool]
+	TrustedWorkspaces ConfigValue[[]string]
+	Aliases           map[string]ConfigValue[Alias]
+	Vars              map[string]ConfigValue[string]
+	URLRewrites       []URLRewrite
+}
+
+type layer struct {
+	source   ConfigSource
+	location string
+	file     *FileConfig
+}
+
+func loadLayers(workspaceRoot string, skipInvalid bool) ([]layer, error) {
+	var layers []layer
+
+	load := func(source ConfigSource, path string) error {
+		cfg, err := loadFileConfig(path)
+		if err != nil {
+			if !skipInvalid {
+				return err
+			}
+			slog.Warn("Ignoring invalid configuration file", "path", path, "err", err)
+			cfg = &FileConfig{}
+		}
+		layers = append(layers, layer{source: source, location: path, file: cfg})
+		return nil
+	}
+
+	if userPath, err := GetUserConfigPath(); err == nil {
+		if err := load(SourceFile, userPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if workspaceRoot != "" {
+		if err := load(SourceWorkspace, GetWorkspaceConfigPath(workspaceRoot)); err != nil {
+			return nil, err
+		}
+	}
+
+	return layers, nil
+}
+
+func Resolve(workspaceRoot string, flags FlagOverrides) (*Resolved, error) {
+	return resolve(workspaceRoot, flags, false)
+}
+
+func resolve(workspaceRoot string, flags FlagOverrides, skipInvalid bool) (*Resolved, error) {
+	layers, err := loadLayers(workspaceRoot, skipInvalid)
+	if err != nil {
+		return nil, err
+	}
+
+	userLayers := make([]layer, 0, 1)
+	for _, l := range layers {
+		if l.source == SourceFile {
+			userLayers = append(userLayers, l)
+		}
+	}
+
+	return &Resolved{
+		Parallel: resolveValue(gws.DefaultParallel, layers,
+			func(f *FileConfig) *int { return f.Parallel }, "parallel", parseInt, flags.Parallel),
+		Format: resolveValue("text", layers,
+			func(f *FileConfig) *string { return f.Format }, "format", parseString, flags.Format),
+		Theme: resolveValue("", layers,
+			func(f *FileConfig) *string { return f.Theme }, "theme", parseString, flags.Theme),
+		TrustHooks: resolveValue("ask", trustHooksLayers(layers),
+			func(f *FileConfig) *string { return f.TrustHooks }, "trust-hooks", parseTrustHooks, flags.TrustHooks),
+		NoColor: resolveValue(false, layers,
+			func(f *FileConfig) *bool { return f.NoColor }, "no-color", strconv.ParseBool, flags.NoColor),
+		OnlyChanges: resolveValue(false, layers,
+			func(f *FileConfig) *bool { return f.OnlyChanges }, "only-changes", strconv.ParseBool, flags.OnlyChanges),
+		StopOnError: resolveValue(false, layers,
+			func(f *FileConfig) *bool { return f.StopOnError }, "stop-on-error", strconv.ParseBool, flags.StopOnError),
+		TrustedWorkspaces: resolveValue([]string{}, userLayers,
+			func(f *FileConfig) *[]string {
+				if f.TrustedWorkspaces == nil {
+					return nil
+				}
+				return &f.TrustedWorkspaces
+			}, "trusted-workspaces", nil, nil),
+		Aliases:     resolveAliases(layers),
+		Vars:        resolveVars(layers),
+		URLRewrites: resolveURLRewrites(layers),
+	}, nil
+}
+
+func trustHooksLayers(layers []layer) []layer {
+	filtered := make([]layer, 0, len(layers))
+	for _, l := range layers {
+		if l.source == SourceWorkspace && l.file.TrustHooks != nil && *l.file.TrustHooks == "all" {
+			slog.Warn("Ignoring trust-hooks: all from the workspace configuration", "path", l.location)
+			continue
+		}
+		filtered = append(filtered, l)
+	}
+	return filtered
+}
+
+func resolveAliases(layers []layer) map[string]ConfigValue[Alias] {
+	aliases := make(map[string]ConfigValue[Alias])
+	for _, l := range layers {
+		for name, alias := range l.file.Aliases {
+			if len(alias) == 0 {
+				continue
+			}
+			aliases[name] = ConfigValue[Alias]{Value: alias, Source: l.source, Location: l.location}
+		}
+	}
+	return aliases
+}
+
+func ResolveAliases(workspaceRoot string) (map[string]ConfigValue[Alias], error) {
+	layers, err := loadLayers(workspaceRoot, false)
+	if err != nil {
+		return nil, err
+	}
+	return resolveAliases(layers), nil
+}
+
+func resolveValue[T any](def T, layers []layer, pick func(*FileConfig) *T, key string, parse func(string) (T, error), flag *T) ConfigValue[T] {
+	value := ConfigValue[T]{Value: def, Source: SourceDefault}
+
+	for _, l := range layers {
+		if v := pick(l.file); v != nil {
+			value = ConfigValue[T]{Value: *v, Source: l.source, Location: l.location}
+		}
+	}
+
+	if envVar := GetEnvVarName(key); envVar != "" && parse != nil {
+		if raw, ok := os.LookupEnv(envVar); ok && raw != "" {
+			parsed, err := parse(raw)
+			if err != nil {
+				slog.Warn("Ignoring invalid environment variable", "var", envVar, "err", err)
+			} else {
+				value = ConfigValue[T]{Value: parsed, Source: SourceEnv, Location: envVar}
+			}
+		}
+	}
+
+	if flag != nil {
+		value = ConfigValue[T]{Value: *flag, Source: SourceFlag, Location: "--" + key}
+	}
+
+	return value
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func parseInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number, got %q", s)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("expected a non-negative number, got %d", n)
+	}
+	return n, nil
+}
+
+func parseTrustHooks(s string) (string, error) {
+	switch s {
+	case "ask", "all", "skip":
+		return s, nil
+	default:
+		return "", fmt.Errorf("expected ask, all or skip, got %q", s)
+	}
+}
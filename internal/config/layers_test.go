@@ -0,0 +1,211 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"gogws/internal/gws"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolve_Layers(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	workspaceRoot := t.TempDir()
+
+	SetUserConfigPath(userPath)
+	defer SetUserConfigPath("")
+
+	writeFile(t, userPath, "parallel: 8\nformat: json\ntrust-hooks: skip\ntrusted-workspaces:\n  - /work/*\n")
+	writeFile(t, GetWorkspaceConfigPath(workspaceRoot), "format: yaml\nstop-on-error: true\ntrusted-workspaces:\n  - /evil\n")
+
+	t.Setenv("GOGWS_TRUST_HOOKS", "all")
+
+	onlyChanges := true
+	resolved, err := Resolve(workspaceRoot, FlagOverrides{OnlyChanges: &onlyChanges})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if resolved.Parallel.Value != 8 || resolved.Parallel.Source != SourceFile {
+		t.Errorf("parallel: got %v from %s", resolved.Parallel.Value, resolved.Parallel.Source)
+	}
+	if resolved.Format.Value != "yaml" || resolved.Format.Source != SourceWorkspace {
+		t.Errorf("format: got %v from %s", resolved.Format.Value, resolved.Format.Source)
+	}
+	if resolved.TrustHooks.Value != "all" || resolved.TrustHooks.Origin() != "env:GOGWS_TRUST_HOOKS" {
+		t.Errorf("trust-hooks: got %v from %s", resolved.TrustHooks.Value, resolved.TrustHooks.Origin())
+	}
+	if !resolved.StopOnError.Value || resolved.StopOnError.Source != SourceWorkspace {
+		t.Errorf("stop-on-error: got %v from %s", resolved.StopOnError.Value, resolved.StopOnError.Source)
+	}
+	if !resolved.OnlyChanges.Value || resolved.OnlyChanges.Source != SourceFlag {
+		t.Errorf("only-changes: got %v from %s", resolved.OnlyChanges.Value, resolved.OnlyChanges.Source)
+	}
+	if resolved.NoColor.Source != SourceDefault {
+		t.Errorf("no-color: expected default, got %s", resolved.NoColor.Source)
+	}
+	if len(resolved.TrustedWorkspaces.Value) != 1 || resolved.TrustedWorkspaces.Value[0] != "/work/*" {
+		t.Errorf("trusted-workspaces must only come from the user file, got %v", resolved.TrustedWorkspaces.Value)
+	}
+}
+
+func TestResolve_WorkspaceCannotTrustHooks(t *testing.T) {
+	SetUserConfigPath(filepath.Join(t.TempDir(), "missing.yaml"))
+	defer SetUserConfigPath("")
+
+	workspaceRoot := t.TempDir()
+	writeFile(t, GetWorkspaceConfigPath(workspaceRoot), "trust-hooks: all\n")
+
+	resolved, err := Resolve(workspaceRoot, FlagOverrides{})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.TrustHooks.Value != "ask" || resolved.TrustHooks.Source != SourceDefault {
+		t.Errorf("trust-hooks: got %v from %s, want the default", resolved.TrustHooks.Value, resolved.TrustHooks.Origin())
+	}
+
+	writeFile(t, GetWorkspaceConfigPath(workspaceRoot), "trust-hooks: skip\n")
+	resolved, err = Resolve(workspaceRoot, FlagOverrides{})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.TrustHooks.Value != "skip" {
+		t.Errorf("a workspace may lower the trust mode, got %v", resolved.TrustHooks.Value)
+	}
+
+	if err := SetValue(ScopeWorkspace, workspaceRoot, "trust-hooks", "all"); err == nil {
+		t.Error("trust-hooks all must not be settable per workspace")
+	}
+}
+
+func TestResolve_SkipInvalid(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	SetUserConfigPath(userPath)
+	defer SetUserConfigPath("")
+
+	writeFile(t, userPath, "parallel: [\n")
+
+	if _, err := Resolve("", FlagOverrides{}); err == nil {
+		t.Error("Expected an error for a malformed user file")
+	}
+
+	resolved, err := resolve("", FlagOverrides{}, true)
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if resolved.Parallel.Value != gws.DefaultParallel || resolved.Parallel.Source != SourceDefault {
+		t.Errorf("parallel: got %v from %s, want the default", resolved.Parallel.Value, resolved.Parallel.Source)
+	}
+}
+
+func TestResolve_Defaults(t *testing.T) {
+	SetUserConfigPath(filepath.Join(t.TempDir(), "missing.yaml"))
+	defer SetUserConfigPath("")
+
+	resolved, err := Resolve("", FlagOverrides{})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if resolved.Parallel.Value != gws.DefaultParallel || resolved.Format.Value != "text" || resolved.TrustHooks.Value != "ask" {
+		t.Errorf("Unexpected defaults: %+v", resolved)
+	}
+}
+
+func TestSetValue_Workspace(t *testing.T) {
+	workspaceRoot := t.TempDir()
+
+	if err := SetValue(ScopeWorkspace, workspaceRoot, "parallel", "12"); err != nil {
+		t.Fatalf("SetValue failed: %v", err)
+	}
+
+	cfg, err := LoadWorkspaceConfig(workspaceRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Parallel == nil || *cfg.Parallel != 12 {
+		t.Errorf("Expected parallel 12 in workspace config, got %v", cfg.Parallel)
+	}
+
+	if err := SetValue(ScopeWorkspace, workspaceRoot, "trusted-workspaces", "/x"); err == nil {
+		t.Error("trusted-workspaces must not be settable per workspace")
+	}
+	if err := SetValue(ScopeWorkspace, workspaceRoot, "parallel", "many"); err == nil {
+		t.Error("Expected an error for a non-numeric parallel value")
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	workspaceRoot := t.TempDir()
+
+	SetUserConfigPath(userPath)
+	defer SetUserConfigPath("")
+
+	writeFile(t, GetWorkspaceConfigPath(workspaceRoot), "vars:\n  GIT_BASE: https://github.com/company\n  TEAM: core\nurl:\n  \"git@github.com:\":\n    instead-of: https://github.com/\n")
+	writeFile(t, userPath, "vars:\n  GIT_BASE: https://mirror.example.com/company\nurl:\n  \"git@mirror.example.com:\":\n    instead-of:\n      - https://mirror.example.com/\n")
+
+	resolved, err := Resolve(workspaceRoot, FlagOverrides{})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	url, err := resolved.ResolveURL("${GIT_BASE}/${TEAM}/api.git")
+	if err != nil || url != "git@mirror.example.com:company/core/api.git" {
+		t.Errorf("user vars must override workspace vars, got %q, %v", url, err)
+	}
+
+	t.Setenv("GOGWS_VAR_GIT_BASE", "https://github.com/other")
+	url, err = resolved.ResolveURL("${GIT_BASE}/api.git")
+	if err != nil || url != "git@github.com:other/api.git" {
+		t.Errorf("environment must override config vars, got %q, %v", url, err)
+	}
+
+	t.Setenv("SECRET_TOKEN", "hunter2")
+	if url, err := resolved.ResolveURL("https://host/${SECRET_TOKEN}.git"); err == nil {
+		t.Errorf("plain environment variables must not be expanded, got %q", url)
+	}
+
+	if _, err := resolved.ResolveURL("${MISSING}/api.git"); err == nil {
+		t.Error("expected an error for an undefined variable")
+	}
+}
+
+func TestURLSources(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	workspaceRoot := t.TempDir()
+
+	SetUserConfigPath(userPath)
+	defer SetUserConfigPath("")
+
+	writeFile(t, userPath, "vars:\n  GIT_BASE: https://git.old-corp.com\nurl:\n  \"git@git.old-corp.com:\":\n    instead-of: https://git.old-corp.com/\n")
+
+	resolved, err := Resolve(workspaceRoot, FlagOverrides{})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	sources := resolved.URLSources("${GIT_BASE}/team/api.git")
+	want := []string{
+		"var GIT_BASE at file:" + userPath,
+		"url rule url.git@git.old-corp.com:.instead-of at file:" + userPath,
+	}
+	if !slices.Equal(sources, want) {
+		t.Errorf("URLSources() = %v, want %v", sources, want)
+	}
+
+	if sources := resolved.URLSources("git@github.com:team/api.git"); len(sources) != 0 {
+		t.Errorf("literal URLs have no sources, got %v", sources)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gogws/internal/gws"
+)
+
+const (
+	VarKeyPrefix    = "var."
+	VarEnvVarPrefix = "GOGWS_VAR_"
+)
+
+var varNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type URLRewrite struct {
+	Base      string
+	InsteadOf string
+	Source    ConfigSource
+	Location  string
+}
+
+func (r URLRewrite) Key() string {
+	return "url." + r.Base + ".instead-of"
+}
+
+func (r URLRewrite) Origin() string {
+	return fmt.Sprintf("%s:%s", r.Source, r.Location)
+}
+
+func resolveVars(layers []layer) map[string]ConfigValue[string] {
+	vars := make(map[string]ConfigValue[string])
+	for _, l := range varLayers(layers) {
+		for name, value := range l.file.Vars {
+			vars[name] = ConfigValue[string]{Value: value, Source: l.source, Location: l.location}
+		}
+	}
+	return vars
+}
+
+func resolveURLRewrites(layers []layer) []URLRewrite {
+	byPrefix := make(map[string]URLRewrite)
+	for _, l := range varLayers(layers) {
+		for base, rule := range l.file.URL {
+			for _, prefix := range rule.InsteadOf {
+				if prefix != "" {
+					byPrefix[prefix] = URLRewrite{Base: base, InsteadOf: prefix, Source: l.source, Location: l.location}
+				}
+			}
+		}
+	}
+
+	rewrites := make([]URLRewrite, 0, len(byPrefix))
+	for _, r := range byPrefix {
+		rewrites = append(rewrites, r)
+	}
+	sort.Slice(rewrites, func(i, j int) bool {
+		if len(rewrites[i].InsteadOf) != len(rewrites[j].InsteadOf) {
+			return len(rewrites[i].InsteadOf) > len(rewrites[j].InsteadOf)
+		}
+		return rewrites[i].InsteadOf < rewrites[j].InsteadOf
+	})
+	return rewrites
+}
+
+func varLayers(layers []layer) []layer {
+	ordered := make([]layer, 0, len(layers))
+	for _, l := range layers {
+		if l.source == SourceWorkspace {
+			ordered = append(ordered, l)
+		}
+	}
+	for _, l := range layers {
+		if l.source != SourceWorkspace {
+			ordered = append(ordered, l)
+		}
+	}
+	return ordered
+}
+
+func (r *Resolved) LookupVar(name string) (ConfigValue[string], bool) {
+	if value, ok := os.LookupEnv(VarEnvVarPrefix + name); ok {
+		return ConfigValue[string]{Value: value, Source: SourceEnv, Location: VarEnvVarPrefix + name}, true
+	}
+	value, ok := r.Vars[name]
+	return value, ok
+}
+
+func (r *Resolved) RewriteURL(url string) string {
+	for _, rw := range r.URLRewrites {
+		if rest, ok := strings.CutPrefix(url, rw.InsteadOf); ok {
+			return rw.Base + rest
+		}
+	}
+	return url
+}
+
+func (r *Resolved) ResolveURL(url string) (string, error) {
+	expanded, err := gws.ExpandURL(url, func(name string) (string, bool) {
+		value, ok := r.LookupVar(name)
+		return value.Value, ok
+	})
+	return r.RewriteURL(expanded), err
+}
+
+func (r *Resolved) URLSources(url string) []string {
+	var sources []string
+	for _, name := range gws.URLVariables(url) {
+		if value, ok := r.LookupVar(name); ok {
+			sources = append(sources, fmt.Sprintf("var %s at %s", name, value.Origin()))
+		}
+	}
+
+	expanded, _ := gws.ExpandURL(url, func(name string) (string, bool) {
+		value, ok := r.LookupVar(name)
+		return value.Value, ok
+	})
+	for _, rw := range r.URLRewrites {
+		if strings.HasPrefix(expanded, rw.InsteadOf) {
+			sources = append(sources, fmt.Sprintf("url rule %s at %s", rw.Key(), rw.Origin()))
+			break
+		}
+	}
+	return sources
+}
+
+func validateVarName(name string) error {
+	if !varNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid variable name %q (letters, digits and underscores)", name)
+	}
+	return nil
+}
@@ -1,39 +1,122 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"gogws/internal/gws"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
-	UserConfigDir  = ".gws"
-	UserConfigFile = "config.yaml"
+	UserConfigDir       = ".gws"
+	UserConfigFile      = "config.yaml"
+	WorkspaceConfigFile = "config.yaml"
 )
 
 type ConfigSource string
 
 const (
-	SourceDefault ConfigSource = "default"
-	SourceFile    ConfigSource = "file"
-	SourceEnv     ConfigSource = "env"
+	SourceDefault   ConfigSource = "default"
+	SourceFile      ConfigSource = "file"
+	SourceWorkspace ConfigSource = "workspace"
+	SourceEnv       ConfigSource = "env"
+	SourceFlag      ConfigSource = "flag"
 )
 
 type ConfigValue[T any] struct {
-	Value  T
-	Source ConfigSource
+	Value    T
+	Source   ConfigSource
+	Location string
+}
+
+func (v ConfigValue[T]) Origin() string {
+	if v.Location == "" {
+		return string(v.Source)
+	}
+	return fmt.Sprintf("%s:%s", v.Source, v.Location)
+}
+
+type FileConfig struct {
+	Parallel          *int               `yaml:"parallel,omitempty"`
+	Format            *string            `yaml:"format,omitempty"`
+	Theme             *string            `yaml:"theme,omitempty"`
+	TrustHooks        *string            `yaml:"trust-hooks,omitempty"`
+	NoColor           *bool              `yaml:"no-color,omitempty"`
+	OnlyChanges       *bool              `yaml:"only-changes,omitempty"`
+	StopOnError       *bool              `yaml:"stop-on-error,omitempty"`
+	TrustedWorkspaces []string           `yaml:"trusted-workspaces,omitempty"`
+	Aliases           map[string]Alias   `yaml:"aliases,omitempty"`
+	Vars              map[string]string  `yaml:"vars,omitempty"`
+	URL               map[string]URLRule `yaml:"url,omitempty"`
+}
+
+type URLRule struct {
+	InsteadOf StringList `yaml:"instead-of"`
+}
+
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*l = StringList{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var items []string
+		if err := node.Decode(&items); err != nil {
+			return err
+		}
+		*l = StringList(items)
+		return nil
+	default:
+		return fmt.Errorf("line %d: expected a string or a list of strings", node.Line)
+	}
 }
 
-type UserConfig struct {
-	TrustedWorkspaces []string `yaml:"trusted-workspaces,omitempty"`
+type Alias []string
+
+func (a *Alias) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*a = Alias{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var steps []string
+		if err := node.Decode(&steps); err != nil {
+			return err
+		}
+		*a = Alias(steps)
+		return nil
+	default:
+		return fmt.Errorf("line %d: alias must be a command or a list of commands", node.Line)
+	}
 }
 
-type UserConfigResolved struct {
-	TrustedWorkspaces ConfigValue[[]string]
+func (a Alias) MarshalYAML() (interface{}, error) {
+	if len(a) == 1 {
+		return a[0], nil
+	}
+	return []string(a), nil
+}
+
+func (a Alias) String() string {
+	return strings.Join(a, " && ")
+}
+
+var userConfigPathOverride string
+
+func SetUserConfigPath(path string) {
+	userConfigPathOverride = path
 }
 
 func GetUserConfigPath() (string, error) {
+	if userConfigPathOverride != "" {
+		return userConfigPathOverride, nil
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -49,76 +132,62 @@ func GetUserConfigDir() (string, error) {
 	return filepath.Join(homeDir, UserConfigDir), nil
 }
 
-func LoadUserConfigResolved() (*UserConfigResolved, error) {
-	resolved := &UserConfigResolved{
-		TrustedWorkspaces: ConfigValue[[]string]{Value: []string{}, Source: SourceDefault},
-	}
-
-	configPath, err := GetUserConfigPath()
-	if err != nil {
-		return resolved, nil
-	}
-
-	data, err := os.ReadFile(configPath)
-	if err == nil {
-		var fileCfg UserConfig
-		if yaml.Unmarshal(data, &fileCfg) == nil {
-			if fileCfg.TrustedWorkspaces != nil {
-				resolved.TrustedWorkspaces = ConfigValue[[]string]{Value: fileCfg.TrustedWorkspaces, Source: SourceFile}
-			}
-		}
-	}
-
-	return resolved, nil
+func GetWorkspaceConfigPath(workspaceRoot string) string {
+	return filepath.Join(workspaceRoot, gws.ConfigDirName, WorkspaceConfigFile)
 }
 
-func LoadUserConfig() (*UserConfig, error) {
-	resolved, err := LoadUserConfigResolved()
+func loadFileConfig(path string) (*FileConfig, error) {
+	cfg := &FileConfig{}
+
+	data, err := os.ReadFile(path)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
 		return nil, err
 	}
-	return &UserConfig{
-		TrustedWorkspaces: resolved.TrustedWorkspaces.Value,
-	}, nil
-}
 
-func SaveUserConfig(cfg *UserConfig) error {
-	configDir, err := GetUserConfigDir()
-	if err != nil {
-		return err
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 	}
+	return cfg, nil
+}
 
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+func saveFileConfig(path string, cfg *FileConfig) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
 
-	configPath, err := GetUserConfigPath()
+	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return err
 	}
 
-	data, err := yaml.Marshal(cfg)
+	return os.WriteFile(path, data, 0644)
+}
+
+func LoadUserConfig() (*FileConfig, error) {
+	configPath, err := GetUserConfigPath()
 	if err != nil {
-		return err
+		return &FileConfig{}, nil
 	}
-
-	return os.WriteFile(configPath, data, 0644)
+	return loadFileConfig(configPath)
 }
 
-func SetUserConfigValue(key string, value interface{}) error {
-	cfg, err := LoadUserConfig()
+func SaveUserConfig(cfg *FileConfig) error {
+	configPath, err := GetUserConfigPath()
 	if err != nil {
 		return err
 	}
+	return saveFileConfig(configPath, cfg)
+}
 
-	switch key {
-	case "trusted-workspaces":
-		if v, ok := value.([]string); ok {
-			cfg.TrustedWorkspaces = v
-		}
-	}
+func LoadWorkspaceConfig(workspaceRoot string) (*FileConfig, error) {
+	return loadFileConfig(GetWorkspaceConfigPath(workspaceRoot))
+}
 
-	return SaveUserConfig(cfg)
+func SaveWorkspaceConfig(workspaceRoot string, cfg *FileConfig) error {
+	return saveFileConfig(GetWorkspaceConfigPath(workspaceRoot), cfg)
 }
 
 func AddTrustedWorkspace(pattern string) error {
@@ -137,33 +206,6 @@ func AddTrustedWorkspace(pattern string) error {
 	return SaveUserConfig(cfg)
 }
 
-func GetUserConfigValue(key string) (interface{}, error) {
-	cfg, err := LoadUserConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	switch key {
-	case "trusted-workspaces":
-		return cfg.TrustedWorkspaces, nil
-	default:
-		return nil, nil
-	}
-}
-
-func GetAvailableConfigKeys() []string {
-	return []string{"trusted-workspaces"}
-}
-
-func GetEnvVarName(key string) string {
-	switch key {
-	case "trusted-workspaces":
-		return ""
-	default:
-		return ""
-	}
-}
-
 func GetUserHooksDir() (string, error) {
 	configDir, err := GetUserConfigDir()
 	if err != nil {
@@ -14,8 +14,10 @@ type ExecuteOptions struct {
 	StopOnError bool
 	Timeout     time.Duration
 	Verbose     bool
+	Settled     []Result
 
 	OnStart    func(cmd RepoCommand)
+	OnOutput   func(cmd RepoCommand, stream, chunk string)
 	OnComplete func(result Result)
 	OnProgress func(current, total int, cmd RepoCommand)
 }
@@ -30,8 +32,8 @@ func DefaultOptions() ExecuteOptions {
 }
 
 func Execute(commands []RepoCommand, opts ExecuteOptions) *ExecuteResult {
-	slog.Debug("Executing commands", "count", len(commands), "parallel", opts.Parallel)
-	if len(commands) == 0 {
+	slog.Debug("Executing commands", "count", len(commands), "settled", len(opts.Settled), "parallel", opts.Parallel)
+	if len(commands) == 0 && len(opts.Settled) == 0 {
 		return NewExecuteResult()
 	}
 
@@ -48,10 +50,37 @@ func Execute(commands []RepoCommand, opts ExecuteOptions) *ExecuteResult {
 		parallel = 1
 	}
 
-	if parallel == 1 {
-		return executeSerial(commands, opts)
+	list := currentObservers()
+	opts = observe(opts, list)
+	for _, o := range list {
+		if o.OnRunStart != nil {
+			o.OnRunStart(len(commands) + len(opts.Settled))
+		}
+	}
+
+	var result *ExecuteResult
+	switch {
+	case len(commands) == 0:
+		result = NewExecuteResult()
+	case parallel == 1:
+		result = executeSerial(commands, opts)
+	default:
+		result = executeParallel(commands, opts, parallel)
+	}
+
+	for _, r := range opts.Settled {
+		if opts.OnComplete != nil {
+			opts.OnComplete(r)
+		}
+		result.add(r)
 	}
-	return executeParallel(commands, opts, parallel)
+
+	for _, o := range list {
+		if o.OnRunFinish != nil {
+			o.OnRunFinish(result)
+		}
+	}
+	return result
 }
 
 func executeSerial(commands []RepoCommand, opts ExecuteOptions) *ExecuteResult {
@@ -67,13 +96,13 @@ func executeSerial(commands []RepoCommand, opts ExecuteOptions) *ExecuteResult {
 			opts.OnProgress(i+1, len(commands), cmd)
 		}
 
-		result := executeSingleCommand(cmd, opts.Timeout)
+		result := executeSingleCommand(cmd, opts)
 
 		if opts.OnComplete != nil {
 			opts.OnComplete(result)
 		}
 
-		execResult.AddResult(result)
+		execResult.add(result)
 
 		if opts.StopOnError && result.IsFailure() {
 			execResult.Stopped = true
@@ -120,7 +149,7 @@ func executeParallel(commands []RepoCommand, opts ExecuteOptions, parallel int)
 				opts.OnStart(c)
 			}
 
-			result := executeSingleCommand(c, opts.Timeout)
+			result := executeSingleCommand(c, opts)
 
 			completed := int(completedCount.Add(1))
 			if opts.OnProgress != nil {
@@ -133,7 +162,7 @@ func executeParallel(commands []RepoCommand, opts ExecuteOptions, parallel int)
 
 			mu.Lock()
 			slog.Debug("Command completed", "repo", c.RepoName, "success", result.Success, "duration", result.Duration)
-			execResult.AddResult(result)
+			execResult.add(result)
 			mu.Unlock()
 
 			if opts.StopOnError && result.IsFailure() {
@@ -153,10 +182,17 @@ func executeParallel(commands []RepoCommand, opts ExecuteOptions, parallel int)
 	return execResult
 }
 
-func executeSingleCommand(cmd RepoCommand, timeout time.Duration) Result {
+func executeSingleCommand(cmd RepoCommand, opts ExecuteOptions) Result {
 	startTime := time.Now()
 
-	stdout, stderr, err := executeCommand(cmd, timeout)
+	var onOutput outputFunc
+	if opts.OnOutput != nil {
+		onOutput = func(stream, chunk string) {
+			opts.OnOutput(cmd, stream, chunk)
+		}
+	}
+
+	stdout, stderr, err := executeCommand(cmd, opts.Timeout, onOutput)
 
 	result := Result{
 		Command:  cmd,
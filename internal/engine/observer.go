@@ -0,0 +1,95 @@
+package engine
+
+import "sync"
+
+type Observer struct {
+	OnRunStart  func(total int)
+	OnRunFinish func(result *ExecuteResult)
+
+	OnStart    func(cmd RepoCommand)
+	OnOutput   func(cmd RepoCommand, stream, chunk string)
+	OnComplete func(result Result)
+	OnProgress func(current, total int, cmd RepoCommand)
+}
+
+type registeredObserver struct {
+	id int
+	Observer
+}
+
+var (
+	observersMu    sync.RWMutex
+	observers      []registeredObserver
+	nextObserverID int
+)
+
+func AddObserver(o Observer) (remove func()) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	nextObserverID++
+	id := nextObserverID
+	observers = append(observers, registeredObserver{id: id, Observer: o})
+
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+		for i, r := range observers {
+			if r.id == id {
+				observers = append(observers[:i], observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func ResetObservers() {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	observers = nil
+}
+
+func currentObservers() []Observer {
+	observersMu.RLock()
+	defer observersMu.RUnlock()
+	list := make([]Observer, len(observers))
+	for i, r := range observers {
+		list[i] = r.Observer
+	}
+	return list
+}
+
+func observe(opts ExecuteOptions, list []Observer) ExecuteOptions {
+	for _, o := range list {
+		opts.OnStart = chain(opts.OnStart, o.OnStart)
+		opts.OnComplete = chain(opts.OnComplete, o.OnComplete)
+		opts.OnOutput = chain3(opts.OnOutput, o.OnOutput)
+		opts.OnProgress = chain3(opts.OnProgress, o.OnProgress)
+	}
+	return opts
+}
+
+func chain[T any](a, b func(T)) func(T) {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return func(v T) {
+		a(v)
+		b(v)
+	}
+}
+
+func chain3[A, B, C any](a, b func(A, B, C)) func(A, B, C) {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return func(x A, y B, z C) {
+		a(x, y, z)
+		b(x, y, z)
+	}
+}
@@ -2,6 +2,8 @@ package engine
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"gogws/internal/ui/cli"
@@ -17,6 +19,7 @@ const (
 type OutputHandler struct {
 	Mode     OutputMode
 	Renderer *cli.Renderer
+	Out      io.Writer
 }
 
 func NewOutputHandler(renderer *cli.Renderer, verbose bool) *OutputHandler {
@@ -27,6 +30,7 @@ func NewOutputHandler(renderer *cli.Renderer, verbose bool) *OutputHandler {
 	return &OutputHandler{
 		Mode:     mode,
 		Renderer: renderer,
+		Out:      os.Stdout,
 	}
 }
 
@@ -38,20 +42,20 @@ func (h *OutputHandler) RenderResult(result Result) {
 
 func (h *OutputHandler) renderVerboseResult(result Result) {
 	if result.IsSkipped() {
-		fmt.Println(h.Renderer.RenderWarning(fmt.Sprintf("%s: skipped (%s)", result.Command.RepoName, result.SkipReason)))
+		fmt.Fprintln(h.Out, h.Renderer.RenderWarning(fmt.Sprintf("%s: skipped (%s)", result.Command.RepoName, result.SkipReason)))
 	} else if result.IsFailure() {
 		errMsg := result.Stderr
 		if errMsg == "" && result.Error != nil {
 			errMsg = result.Error.Error()
 		}
-		fmt.Println(h.Renderer.RenderError(fmt.Sprintf("%s: %s", result.Command.RepoName, strings.TrimSpace(errMsg))))
+		fmt.Fprintln(h.Out, h.Renderer.RenderError(fmt.Sprintf("%s: %s", result.Command.RepoName, strings.TrimSpace(errMsg))))
 	} else {
-		fmt.Println(h.Renderer.RenderSuccess(result.Command.RepoName))
+		fmt.Fprintln(h.Out, h.Renderer.RenderSuccess(result.Command.RepoName))
 	}
 }
 
 func (h *OutputHandler) RenderSummary(execResult *ExecuteResult, actionName string) {
-	fmt.Println()
+	fmt.Fprintln(h.Out)
 
 	if h.Mode == OutputModeStacked {
 		h.renderStackedSummary(execResult, actionName)
@@ -71,24 +75,28 @@ func (h *OutputHandler) renderStackedSummary(execResult *ExecuteResult, actionNa
 			for i, r := range succeeded {
 				names[i] = r.Command.RepoName
 			}
-			fmt.Println(h.Renderer.RenderSuccess(fmt.Sprintf("%s: %s", actionName, strings.Join(names, ", "))))
+			fmt.Fprintln(h.Out, h.Renderer.RenderSuccess(fmt.Sprintf("%s: %s", actionName, strings.Join(names, ", "))))
 		} else {
-			fmt.Println(h.Renderer.RenderSuccess(fmt.Sprintf("%s %d repositories successfully", actionName, len(succeeded))))
+			fmt.Fprintln(h.Out, h.Renderer.RenderSuccess(fmt.Sprintf("%s %d repositories successfully", actionName, len(succeeded))))
 		}
 	}
 
 	if len(skipped) > 0 {
 		if len(skipped) <= 3 {
 			for _, r := range skipped {
-				fmt.Println(h.Renderer.RenderWarning(fmt.Sprintf("%s: skipped (%s)", r.Command.RepoName, r.SkipReason)))
+				fmt.Fprintln(h.Out, h.Renderer.RenderWarning(fmt.Sprintf("%s: skipped (%s)", r.Command.RepoName, r.SkipReason)))
 			}
 		} else {
-			fmt.Println(h.Renderer.RenderWarning(fmt.Sprintf("Skipped %d repositories", len(skipped))))
+			fmt.Fprintln(h.Out, h.Renderer.RenderWarning(fmt.Sprintf("Skipped %d repositories:", len(skipped))))
+			reasons, counts := groupSkipReasons(skipped)
+			for _, reason := range reasons {
+				fmt.Fprintln(h.Out, h.Renderer.RenderWarning(fmt.Sprintf("  %s (%d)", reason, counts[reason])))
+			}
 		}
 	}
 
 	if len(failed) > 0 {
-		fmt.Println(h.Renderer.RenderError(fmt.Sprintf("Failed (%d):", len(failed))))
+		fmt.Fprintln(h.Out, h.Renderer.RenderError(fmt.Sprintf("Failed (%d):", len(failed))))
 		for _, r := range failed {
 			errMsg := r.Stderr
 			if errMsg == "" && r.Error != nil {
@@ -98,13 +106,25 @@ func (h *OutputHandler) renderStackedSummary(execResult *ExecuteResult, actionNa
 			if errMsg == "" {
 				errMsg = "unknown error"
 			}
-			fmt.Println(h.Renderer.RenderError(fmt.Sprintf("  %s: %s", r.Command.RepoName, errMsg)))
+			fmt.Fprintln(h.Out, h.Renderer.RenderError(fmt.Sprintf("  %s: %s", r.Command.RepoName, errMsg)))
 		}
 	}
 
 	if execResult.Stopped {
-		fmt.Println(h.Renderer.RenderWarning(fmt.Sprintf("Execution stopped: %s", execResult.StopReason)))
+		fmt.Fprintln(h.Out, h.Renderer.RenderWarning(fmt.Sprintf("Execution stopped: %s", execResult.StopReason)))
+	}
+}
+
+func groupSkipReasons(skipped []Result) ([]string, map[string]int) {
+	var reasons []string
+	counts := make(map[string]int)
+	for _, r := range skipped {
+		if _, seen := counts[r.SkipReason]; !seen {
+			reasons = append(reasons, r.SkipReason)
+		}
+		counts[r.SkipReason]++
 	}
+	return reasons, counts
 }
 
 func (h *OutputHandler) renderSimpleSummary(execResult *ExecuteResult, actionName string) {
@@ -125,17 +145,17 @@ func (h *OutputHandler) renderSimpleSummary(execResult *ExecuteResult, actionNam
 
 	summary := strings.Join(parts, ", ")
 	if failedCount > 0 {
-		fmt.Println(h.Renderer.RenderWarning(fmt.Sprintf("%s: %s", actionName, summary)))
+		fmt.Fprintln(h.Out, h.Renderer.RenderWarning(fmt.Sprintf("%s: %s", actionName, summary)))
 	} else {
-		fmt.Println(h.Renderer.RenderSuccess(fmt.Sprintf("%s: %s", actionName, summary)))
+		fmt.Fprintln(h.Out, h.Renderer.RenderSuccess(fmt.Sprintf("%s: %s", actionName, summary)))
 	}
 }
 
 func (h *OutputHandler) RenderProgress(current, total int, repoName string) {
 	percentage := float64(current) / float64(total) * 100
-	fmt.Printf("\r[%d/%d] %.0f%% - %s", current, total, percentage, repoName)
+	fmt.Fprintf(h.Out, "\r[%d/%d] %.0f%% - %s", current, total, percentage, repoName)
 }
 
 func (h *OutputHandler) ClearProgress() {
-	fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
+	fmt.Fprintf(h.Out, "\r%s\r", strings.Repeat(" ", 80))
 }
@@ -47,6 +47,24 @@ func NewExecuteResult() *ExecuteResult {
 }
 
 func (r *ExecuteResult) AddResult(result Result) {
+	r.add(result)
+	for _, o := range currentObservers() {
+		if o.OnComplete != nil {
+			o.OnComplete(result)
+		}
+	}
+}
+
+func (r *ExecuteResult) Merge(other *ExecuteResult) {
+	r.Results = append(r.Results, other.Results...)
+	r.TotalDuration += other.TotalDuration
+	if other.Stopped {
+		r.Stopped = true
+		r.StopReason = other.StopReason
+	}
+}
+
+func (r *ExecuteResult) add(result Result) {
 	r.Results = append(r.Results, result)
 }
 
@@ -10,39 +10,43 @@ import (
 	"time"
 )
 
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+type outputFunc func(stream, chunk string)
+
+type streamWriter struct {
+	buf    bytes.Buffer
+	stream string
+	emit   outputFunc
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if w.emit != nil && len(p) > 0 {
+		w.emit(w.stream, string(p))
+	}
+	return w.buf.Write(p)
+}
+
 func ExecuteGit(repoPath string, args ...string) (stdout, stderr string, err error) {
 	return ExecuteGitWithTimeout(repoPath, 0, args...)
 }
 
 func ExecuteGitWithTimeout(repoPath string, timeout time.Duration, args ...string) (stdout, stderr string, err error) {
-	slog.Debug("Executing git command", "repoPath", repoPath, "args", args, "timeout", timeout)
-	var ctx context.Context
-	var cancel context.CancelFunc
+	return executeGit(repoPath, timeout, nil, args...)
+}
 
-	if timeout > 0 {
-		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-	} else {
-		ctx = context.Background()
-	}
+func executeGit(repoPath string, timeout time.Duration, onOutput outputFunc, args ...string) (stdout, stderr string, err error) {
+	slog.Debug("Executing git command", "repoPath", repoPath, "args", args, "timeout", timeout)
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = repoPath
 
-	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = &stdoutBuf
-	cmd.Stderr = &stderrBuf
-
-	err = cmd.Run()
-
-	stdout = stdoutBuf.String()
-	stderr = stderrBuf.String()
-
-	if ctx.Err() == context.DeadlineExceeded {
-		err = fmt.Errorf("command timed out after %v", timeout)
-	}
-
-	return
+	return run(ctx, cmd, timeout, onOutput)
 }
 
 func ExecuteShell(repoPath, command string) (stdout, stderr string, err error) {
@@ -50,15 +54,12 @@ func ExecuteShell(repoPath, command string) (stdout, stderr string, err error) {
 }
 
 func ExecuteShellWithTimeout(repoPath, command string, timeout time.Duration) (stdout, stderr string, err error) {
-	var ctx context.Context
-	var cancel context.CancelFunc
+	return executeShell(repoPath, command, timeout, nil)
+}
 
-	if timeout > 0 {
-		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-	} else {
-		ctx = context.Background()
-	}
+func executeShell(repoPath, command string, timeout time.Duration, onOutput outputFunc) (stdout, stderr string, err error) {
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
 
 	var cmd *exec.Cmd
 	if runtime.GOOS == "windows" {
@@ -68,14 +69,26 @@ func ExecuteShellWithTimeout(repoPath, command string, timeout time.Duration) (s
 	}
 	cmd.Dir = repoPath
 
-	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = &stdoutBuf
-	cmd.Stderr = &stderrBuf
+	return run(ctx, cmd, timeout, onOutput)
+}
+
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func run(ctx context.Context, cmd *exec.Cmd, timeout time.Duration, onOutput outputFunc) (stdout, stderr string, err error) {
+	stdoutW := &streamWriter{stream: StreamStdout, emit: onOutput}
+	stderrW := &streamWriter{stream: StreamStderr, emit: onOutput}
+	cmd.Stdout = stdoutW
+	cmd.Stderr = stderrW
 
 	err = cmd.Run()
 
-	stdout = stdoutBuf.String()
-	stderr = stderrBuf.String()
+	stdout = stdoutW.buf.String()
+	stderr = stderrW.buf.String()
 
 	if ctx.Err() == context.DeadlineExceeded {
 		err = fmt.Errorf("command timed out after %v", timeout)
@@ -84,13 +97,13 @@ func ExecuteShellWithTimeout(repoPath, command string, timeout time.Duration) (s
 	return
 }
 
-func executeCommand(cmd RepoCommand, timeout time.Duration) (stdout, stderr string, err error) {
+func executeCommand(cmd RepoCommand, timeout time.Duration, onOutput outputFunc) (stdout, stderr string, err error) {
 	switch cmd.Type {
 	case CommandTypeGit:
-		return ExecuteGitWithTimeout(cmd.RepoPath, timeout, cmd.Args...)
+		return executeGit(cmd.RepoPath, timeout, onOutput, cmd.Args...)
 	case CommandTypeShell:
 		if len(cmd.Args) > 0 {
-			return ExecuteShellWithTimeout(cmd.RepoPath, cmd.Args[0], timeout)
+			return executeShell(cmd.RepoPath, cmd.Args[0], timeout, onOutput)
 		}
 		return "", "", fmt.Errorf("shell command requires at least one argument")
 	case CommandTypeCustom:
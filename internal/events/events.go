@@ -0,0 +1,201 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/export"
+	"gogws/internal/hooks"
+)
+
+const FormatNDJSON = "ndjson"
+
+const (
+	RunStarted    = "run_started"
+	RunFinished   = "run_finished"
+	RepoStarted   = "repo_started"
+	RepoOutput    = "repo_output"
+	RepoCompleted = "repo_completed"
+	HookStarted   = "hook_started"
+	HookFinished  = "hook_finished"
+)
+
+type Event struct {
+	Schema     string    `json:"schema"`
+	Seq        int       `json:"seq"`
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	Command    string    `json:"command"`
+	Run        int       `json:"run,omitempty"`
+	Repo       string    `json:"repo,omitempty"`
+	Action     string    `json:"action,omitempty"`
+	Stream     string    `json:"stream,omitempty"`
+	Data       string    `json:"data,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	SkipReason string    `json:"skip_reason,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs *int64    `json:"duration_ms,omitempty"`
+	Completed  *int      `json:"completed,omitempty"`
+	Total      *int      `json:"total,omitempty"`
+	Succeeded  *int      `json:"succeeded,omitempty"`
+	Failed     *int      `json:"failed,omitempty"`
+	Skipped    *int      `json:"skipped,omitempty"`
+	Hook       string    `json:"hook,omitempty"`
+	HookName   string    `json:"hook_name,omitempty"`
+	Origin     string    `json:"origin,omitempty"`
+}
+
+type Stream struct {
+	mu        sync.Mutex
+	w         io.Writer
+	command   string
+	seq       int
+	run       int
+	running   bool
+	completed int
+	total     int
+	now       func() time.Time
+}
+
+func ValidateFormat(format string) error {
+	if format != FormatNDJSON {
+		return fmt.Errorf("unsupported events format: %s (supported: %s)", format, FormatNDJSON)
+	}
+	return nil
+}
+
+func NewStream(w io.Writer, command string) *Stream {
+	return &Stream{w: w, command: command, now: time.Now}
+}
+
+func (s *Stream) SetCommand(command string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.command = command
+}
+
+func (s *Stream) Attach() {
+	engine.AddObserver(s.EngineObserver())
+	hooks.SetObserver(s.HookObserver())
+}
+
+func (s *Stream) EngineObserver() engine.Observer {
+	return engine.Observer{
+		OnRunStart: func(total int) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.run++
+			s.running = true
+			s.completed = 0
+			s.total = total
+			s.emit(Event{Type: RunStarted, Run: s.run, Total: intPtr(total)})
+		},
+		OnRunFinish: func(result *engine.ExecuteResult) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.running = false
+			s.emit(Event{
+				Type:       RunFinished,
+				Run:        s.run,
+				Total:      intPtr(result.TotalCount()),
+				Succeeded:  intPtr(result.SuccessCount()),
+				Failed:     intPtr(result.FailedCount()),
+				Skipped:    intPtr(result.SkippedCount()),
+				DurationMs: int64Ptr(result.TotalDuration.Milliseconds()),
+			})
+		},
+		OnStart: func(cmd engine.RepoCommand) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.emit(Event{Type: RepoStarted, Run: s.run, Repo: cmd.RepoName, Action: export.ResultAction(cmd, s.command)})
+		},
+		OnOutput: func(cmd engine.RepoCommand, stream, chunk string) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.emit(Event{Type: RepoOutput, Run: s.run, Repo: cmd.RepoName, Stream: stream, Data: chunk})
+		},
+		OnComplete: func(r engine.Result) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			e := Event{
+				Type:       RepoCompleted,
+				Repo:       r.Command.RepoName,
+				Action:     export.ResultAction(r.Command, s.command),
+				Status:     export.ResultSuccess,
+				SkipReason: r.SkipReason,
+				DurationMs: int64Ptr(r.Duration.Milliseconds()),
+			}
+			if s.running {
+				s.completed++
+				e.Run = s.run
+				e.Completed = intPtr(s.completed)
+				e.Total = intPtr(s.total)
+			}
+			switch {
+			case r.IsSkipped():
+				e.Status = export.ResultSkipped
+			case r.IsFailure():
+				e.Status = export.ResultFailed
+				e.Error = export.ResultError(r)
+			}
+			s.emit(e)
+		},
+	}
+}
+
+func (s *Stream) HookObserver() hooks.Observer {
+	return hooks.Observer{
+		OnStart: func(hook *hooks.HookInfo, ctx hooks.Context) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.emit(hookEvent(HookStarted, hook))
+		},
+		OnFinish: func(hook *hooks.HookInfo, ctx hooks.Context, duration time.Duration, err error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			e := hookEvent(HookFinished, hook)
+			e.Status = export.ResultSuccess
+			e.DurationMs = int64Ptr(duration.Milliseconds())
+			if err != nil {
+				e.Status = export.ResultFailed
+				e.Error = err.Error()
+			}
+			s.emit(e)
+		},
+	}
+}
+
+func (s *Stream) emit(e Event) {
+	s.seq++
+	e.Schema = export.SchemaID("events")
+	e.Seq = s.seq
+	e.Time = s.now()
+	e.Command = s.command
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+	s.w.Write(data)
+}
+
+func hookEvent(eventType string, hook *hooks.HookInfo) Event {
+	e := Event{Type: eventType, Hook: string(hook.Name), Origin: string(hook.Origin)}
+	if hook.Spec != nil {
+		e.HookName = hook.Spec.DisplayName()
+	}
+	return e
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gogws/internal/engine"
+)
+
+func TestStreamOrderUnderParallelExecution(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStream(&buf, "fetch")
+
+	engine.ResetObservers()
+	engine.AddObserver(stream.EngineObserver())
+	defer engine.ResetObservers()
+
+	dir := t.TempDir()
+	names := []string{"a", "b", "c", "d", "e"}
+	commands := make([]engine.RepoCommand, len(names))
+	for i, name := range names {
+		commands[i] = engine.NewShellCommand(dir, name, "echo "+name)
+	}
+	engine.Execute(commands, engine.ExecuteOptions{Parallel: 3})
+
+	var got []Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, e)
+	}
+
+	if len(got) != 2+3*len(names) {
+		t.Fatalf("expected %d events, got %d", 2+3*len(names), len(got))
+	}
+	if got[0].Type != RunStarted || got[len(got)-1].Type != RunFinished {
+		t.Errorf("run events out of place: first=%s last=%s", got[0].Type, got[len(got)-1].Type)
+	}
+
+	stage := make(map[string]string)
+	next := map[string]string{RepoStarted: "", RepoOutput: RepoStarted, RepoCompleted: RepoOutput}
+	completed := 0
+	for i, e := range got {
+		if e.Seq != i+1 {
+			t.Errorf("event %d has seq %d", i, e.Seq)
+		}
+		if e.Repo == "" {
+			continue
+		}
+		if stage[e.Repo] != next[e.Type] {
+			t.Errorf("%s: %s after %q", e.Repo, e.Type, stage[e.Repo])
+		}
+		stage[e.Repo] = e.Type
+		if e.Type == RepoOutput && e.Data != e.Repo+"\n" {
+			t.Errorf("%s: unexpected output %q", e.Repo, e.Data)
+		}
+		if e.Type == RepoCompleted {
+			completed++
+			if *e.Completed != completed {
+				t.Errorf("%s: completed = %d, want %d", e.Repo, *e.Completed, completed)
+			}
+		}
+	}
+}
+
+func TestStreamIncludesResultsBuiltOutsideTheEngine(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStream(&buf, "fetch")
+	engine.ResetObservers()
+	engine.AddObserver(stream.EngineObserver())
+	defer engine.ResetObservers()
+
+	dir := t.TempDir()
+	skipped := engine.Skip(engine.NewGitCommand(dir, "docs", "fetch"), "not cloned yet")
+	result := engine.Execute([]engine.RepoCommand{engine.NewShellCommand(dir, "api", "true")}, engine.ExecuteOptions{
+		Settled: []engine.Result{skipped},
+	})
+	result.AddResult(engine.Skip(engine.NewGitCommand(dir, "web", "fetch"), "already exists"))
+
+	var got []Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, e)
+	}
+
+	completed := make(map[string]Event)
+	var finished Event
+	for _, e := range got {
+		switch e.Type {
+		case RepoCompleted:
+			completed[e.Repo] = e
+		case RunFinished:
+			finished = e
+		}
+	}
+
+	if len(completed) != 3 {
+		t.Fatalf("expected repo_completed for api, docs and web, got %v", completed)
+	}
+	if docs := completed["docs"]; docs.Status != "skipped" || docs.Run != 1 || *docs.Total != 2 {
+		t.Errorf("settled result should be part of the run: %+v", docs)
+	}
+	if finished.Total == nil || *finished.Total != 2 || *finished.Skipped != 1 {
+		t.Errorf("run_finished should count settled results: %+v", finished)
+	}
+	if web := completed["web"]; web.Run != 0 || web.Total != nil {
+		t.Errorf("result added after the run should not carry run counters: %+v", web)
+	}
+}
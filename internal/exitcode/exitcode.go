@@ -0,0 +1,86 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gogws/internal/engine"
+)
+
+const (
+	OK             = 0
+	Failure        = 1
+	Usage          = 2
+	PartialFailure = 3
+	Changes        = 4
+)
+
+var ErrNoWorkspace = New(Usage, errors.New("no workspace found (no .projects.gws file)"))
+
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(code int, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+func Silent(code int) error {
+	return &Error{Code: code}
+}
+
+func IsSilent(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Err == nil
+}
+
+func FromResult(result *engine.ExecuteResult, command string) error {
+	failed := result.FailedCount()
+	if failed == 0 {
+		return nil
+	}
+	return New(PartialFailure, fmt.Errorf("%s: %d of %d repositories failed", command, failed, result.TotalCount()))
+}
+
+var usagePrefixes = []string{
+	"flag needs an argument:",
+	"unknown flag:",
+	"unknown shorthand flag:",
+	"unknown command",
+	"invalid argument",
+	"accepts ",
+	"requires at least",
+	"requires at most",
+}
+
+func Code(err error) int {
+	if err == nil {
+		return OK
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	msg := err.Error()
+	for _, prefix := range usagePrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			return Usage
+		}
+	}
+	return Failure
+}
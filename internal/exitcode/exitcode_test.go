@@ -0,0 +1,53 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gogws/internal/engine"
+)
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, OK},
+		{"plain error", errors.New("boom"), Failure},
+		{"usage error", New(Usage, errors.New("bad format")), Usage},
+		{"wrapped", fmt.Errorf("alias: %w", New(PartialFailure, errors.New("failed"))), PartialFailure},
+		{"silent", Silent(Changes), Changes},
+		{"cobra flag error", errors.New("unknown flag: --nope"), Usage},
+		{"cobra args error", errors.New("accepts 1 arg(s), received 2"), Usage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.err); got != tt.want {
+				t.Errorf("Code() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromResult(t *testing.T) {
+	result := engine.NewExecuteResult()
+	result.AddResult(engine.Result{Command: engine.NewGitCommand("/ws/a", "a", "fetch"), Success: true})
+	result.AddResult(engine.Skip(engine.NewGitCommand("/ws/b", "b", "fetch"), "not cloned yet"))
+
+	if err := FromResult(result, "fetch"); err != nil {
+		t.Fatalf("expected nil for skipped-only result, got %v", err)
+	}
+
+	result.AddResult(engine.Result{Command: engine.NewGitCommand("/ws/c", "c", "fetch"), Error: errors.New("exit status 1")})
+
+	err := FromResult(result, "fetch")
+	if Code(err) != PartialFailure {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+	if err.Error() != "fetch: 1 of 3 repositories failed" {
+		t.Errorf("unexpected message: %s", err)
+	}
+}
@@ -0,0 +1,63 @@
+package export
+
+import "gogws/internal/git"
+
+type ApplyOutput struct {
+	Schema        string              `json:"schema" yaml:"schema"`
+	Source        string              `json:"source" yaml:"source"`
+	Branch        string              `json:"branch" yaml:"branch"`
+	DryRun        bool                `json:"dry_run" yaml:"dry_run"`
+	Total         int                 `json:"total" yaml:"total"`
+	Applied       int                 `json:"applied" yaml:"applied"`
+	Conflicts     int                 `json:"conflicts" yaml:"conflicts"`
+	NotApplicable int                 `json:"not_applicable" yaml:"not_applicable"`
+	Skipped       int                 `json:"skipped" yaml:"skipped"`
+	Failed        int                 `json:"failed" yaml:"failed"`
+	Results       []ApplyResultOutput `json:"results" yaml:"results"`
+}
+
+type ApplyResultOutput struct {
+	Repo      string   `json:"repo" yaml:"repo"`
+	Outcome   string   `json:"outcome" yaml:"outcome"`
+	Branch    string   `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commit    string   `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Conflicts []string `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	Reason    string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+func NewApplyOutput(source, branch string, dryRun bool, results []git.ApplyResult) ApplyOutput {
+	output := ApplyOutput{
+		Schema:  SchemaID("apply"),
+		Source:  source,
+		Branch:  branch,
+		DryRun:  dryRun,
+		Total:   len(results),
+		Results: make([]ApplyResultOutput, len(results)),
+	}
+
+	for i, r := range results {
+		switch r.Outcome {
+		case git.ApplyClean:
+			output.Applied++
+		case git.ApplyConflicts:
+			output.Conflicts++
+		case git.ApplyNotApplicable:
+			output.NotApplicable++
+		case git.ApplySkipped:
+			output.Skipped++
+		default:
+			output.Failed++
+		}
+
+		output.Results[i] = ApplyResultOutput{
+			Repo:      r.Repo,
+			Outcome:   string(r.Outcome),
+			Branch:    r.Branch,
+			Commit:    r.Commit,
+			Conflicts: r.Conflicts,
+			Reason:    r.Reason,
+		}
+	}
+
+	return output
+}
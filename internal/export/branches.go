@@ -0,0 +1,74 @@
+package export
+
+import (
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+const (
+	PruneDelete  = "delete"
+	PruneDeleted = "deleted"
+	PruneKeep    = "keep"
+	PruneFailed  = "failed"
+)
+
+type PruneOutput struct {
+	Schema   string              `json:"schema" yaml:"schema"`
+	DryRun   bool                `json:"dry_run" yaml:"dry_run"`
+	Total    int                 `json:"total" yaml:"total"`
+	Deleted  int                 `json:"deleted" yaml:"deleted"`
+	Kept     int                 `json:"kept" yaml:"kept"`
+	Branches []PruneBranchOutput `json:"branches" yaml:"branches"`
+	Errors   []RepoErrorOutput   `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type PruneBranchOutput struct {
+	Repo        string    `json:"repo" yaml:"repo"`
+	Branch      string    `json:"branch" yaml:"branch"`
+	Reasons     []string  `json:"reasons" yaml:"reasons"`
+	CommittedAt time.Time `json:"committed_at" yaml:"committed_at"`
+	Unpushed    int       `json:"unpushed" yaml:"unpushed"`
+	Action      string    `json:"action" yaml:"action"`
+	Keep        string    `json:"keep,omitempty" yaml:"keep,omitempty"`
+	Error       string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type PruneResult struct {
+	git.PruneCandidate
+	Action string
+	Error  string
+}
+
+func NewPruneOutput(results []PruneResult, failed []engine.Result, dryRun bool) PruneOutput {
+	output := PruneOutput{
+		Schema:   SchemaID("branches"),
+		DryRun:   dryRun,
+		Total:    len(results),
+		Branches: make([]PruneBranchOutput, len(results)),
+		Errors:   NewRepoErrors(failed),
+	}
+
+	for i, r := range results {
+		switch r.Action {
+		case PruneDeleted:
+			output.Deleted++
+		case PruneKeep:
+			output.Kept++
+		}
+
+		output.Branches[i] = PruneBranchOutput{
+			Repo:        r.Repo,
+			Branch:      r.Branch,
+			Reasons:     r.Reasons,
+			CommittedAt: r.CommittedAt,
+			Unpushed:    r.Unpushed,
+			Action:      r.Action,
+			Keep:        r.Keep,
+			Error:       r.Error,
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gogws/internal/git"
+)
+
+var AvailableColumns = []string{
+	"path", "branch", "ahead", "behind", "dirty", "uncommitted", "untracked",
+	"remote", "default", "ahead-default", "behind-default",
+	"last-commit", "commit", "author", "subject",
+}
+
+func ParseColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !isColumn(name) {
+			return nil, fmt.Errorf("unknown column: %s (available: %s)", name, strings.Join(AvailableColumns, ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given (available: %s)", strings.Join(AvailableColumns, ", "))
+	}
+	return columns, nil
+}
+
+func isColumn(name string) bool {
+	for _, c := range AvailableColumns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+func ToColumns(statuses []git.RepositoryStatus, columns []string) [][]string {
+	now := time.Now()
+	repos := NewStatusOutput(statuses).Repositories
+
+	rows := make([][]string, len(repos))
+	for i, repo := range repos {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = columnValue(repo, column, now)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func columnValue(repo RepositoryStatusOutput, column string, now time.Time) string {
+	if column == "path" {
+		return repo.Path
+	}
+	if !repo.Exists {
+		if column == "branch" {
+			return "(missing)"
+		}
+		return "-"
+	}
+
+	switch column {
+	case "branch":
+		return repo.Branch
+	case "ahead":
+		return strconv.Itoa(repo.Ahead)
+	case "behind":
+		return strconv.Itoa(repo.Behind)
+	case "dirty":
+		if repo.Clean {
+			return "no"
+		}
+		return "yes"
+	case "uncommitted":
+		return strconv.Itoa(repo.Uncommitted)
+	case "untracked":
+		return strconv.Itoa(repo.Untracked)
+	case "remote":
+		if repo.HasRemote {
+			return "yes"
+		}
+		return "no"
+	case "default":
+		if repo.DefaultBranch == "" {
+			return "-"
+		}
+		if !repo.OnDefault {
+			return repo.DefaultBranch + " (off)"
+		}
+		return repo.DefaultBranch
+	case "ahead-default", "behind-default":
+		if repo.DefaultBranch == "" {
+			return "-"
+		}
+		if column == "ahead-default" {
+			return strconv.Itoa(repo.AheadDefault)
+		}
+		return strconv.Itoa(repo.BehindDefault)
+	}
+
+	if repo.LastCommit == nil {
+		return "-"
+	}
+
+	switch column {
+	case "last-commit":
+		return RelativeTime(repo.LastCommit.Date, now)
+	case "commit":
+		return repo.LastCommit.Hash
+	case "author":
+		return repo.LastCommit.Author
+	case "subject":
+		return repo.LastCommit.Subject
+	default:
+		return ""
+	}
+}
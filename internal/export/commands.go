@@ -0,0 +1,72 @@
+package export
+
+import "gogws/internal/git"
+
+const (
+	CheckPresent = "present"
+	CheckMissing = "missing"
+	CheckUnknown = "unknown"
+)
+
+type CheckOutput struct {
+	Schema       string                  `json:"schema" yaml:"schema"`
+	Total        int                     `json:"total" yaml:"total"`
+	Present      int                     `json:"present" yaml:"present"`
+	Missing      int                     `json:"missing" yaml:"missing"`
+	Unknown      int                     `json:"unknown" yaml:"unknown"`
+	Drifted      int                     `json:"drifted" yaml:"drifted"`
+	Repositories []CheckRepositoryOutput `json:"repositories" yaml:"repositories"`
+}
+
+type CheckRepositoryOutput struct {
+	Path        string             `json:"path" yaml:"path"`
+	State       string             `json:"state" yaml:"state"`
+	RemoteDrift []git.RemoteChange `json:"remote_drift,omitempty" yaml:"remote_drift,omitempty"`
+}
+
+func NewCheckOutput(present, missing, unknown []string, drift map[string][]git.RemoteChange) CheckOutput {
+	output := CheckOutput{
+		Schema:       SchemaID("check"),
+		Total:        len(present) + len(missing) + len(unknown),
+		Present:      len(present),
+		Missing:      len(missing),
+		Unknown:      len(unknown),
+		Drifted:      len(drift),
+		Repositories: make([]CheckRepositoryOutput, 0, len(present)+len(missing)+len(unknown)),
+	}
+
+	for _, group := range []struct {
+		state string
+		paths []string
+	}{{CheckPresent, present}, {CheckMissing, missing}, {CheckUnknown, unknown}} {
+		for _, path := range group.paths {
+			output.Repositories = append(output.Repositories, CheckRepositoryOutput{Path: path, State: group.state, RemoteDrift: drift[path]})
+		}
+	}
+
+	return output
+}
+
+type InitOutput struct {
+	Schema    string            `json:"schema" yaml:"schema"`
+	Target    string            `json:"target" yaml:"target"`
+	File      string            `json:"file,omitempty" yaml:"file,omitempty"`
+	Created   bool              `json:"created" yaml:"created"`
+	Message   string            `json:"message,omitempty" yaml:"message,omitempty"`
+	Entries   []InitEntryOutput `json:"entries,omitempty" yaml:"entries,omitempty"`
+	Gitignore string            `json:"gitignore,omitempty" yaml:"gitignore,omitempty"`
+}
+
+type InitEntryOutput struct {
+	Path    string         `json:"path" yaml:"path"`
+	Remotes []RemoteOutput `json:"remotes,omitempty" yaml:"remotes,omitempty"`
+}
+
+type RemoteOutput struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+func NewInitOutput(target string) InitOutput {
+	return InitOutput{Schema: SchemaID("init"), Target: target}
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"gogws/internal/git"
+)
+
+func ToCSV(statuses []git.RepositoryStatus) (string, error) {
+	rows := [][]string{{
+		"path", "exists", "clean", "branch", "ahead", "behind", "uncommitted", "untracked",
+		"has_remote", "last_commit", "last_commit_date", "error",
+	}}
+
+	for _, repo := range NewStatusOutput(statuses).Repositories {
+		lastCommit, lastCommitDate := "", ""
+		if repo.LastCommit != nil {
+			lastCommit = repo.LastCommit.Hash
+			lastCommitDate = repo.LastCommit.Date.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			repo.Path,
+			strconv.FormatBool(repo.Exists),
+			strconv.FormatBool(repo.Clean),
+			repo.Branch,
+			strconv.Itoa(repo.Ahead),
+			strconv.Itoa(repo.Behind),
+			strconv.Itoa(repo.Uncommitted),
+			strconv.Itoa(repo.Untracked),
+			strconv.FormatBool(repo.HasRemote),
+			lastCommit,
+			lastCommitDate,
+			repo.Error,
+		})
+	}
+
+	return writeCSV(rows)
+}
+
+func ResultsToCSV(output ResultsOutput) (string, error) {
+	rows := [][]string{{"path", "action", "status", "duration_ms", "skip_reason", "error"}}
+
+	for _, r := range output.Results {
+		rows = append(rows, []string{
+			r.Path,
+			r.Action,
+			r.Status,
+			strconv.FormatInt(r.DurationMs, 10),
+			r.SkipReason,
+			r.Error,
+		})
+	}
+
+	return writeCSV(rows)
+}
+
+func writeCSV(rows [][]string) (string, error) {
+	var output strings.Builder
+	w := csv.NewWriter(&output)
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(output.String(), "\n"), nil
+}
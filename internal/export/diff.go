@@ -0,0 +1,50 @@
+package export
+
+import (
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+type DiffOutput struct {
+	Schema     string            `json:"schema" yaml:"schema"`
+	Staged     bool              `json:"staged" yaml:"staged"`
+	Total      int               `json:"total" yaml:"total"`
+	Insertions int               `json:"insertions" yaml:"insertions"`
+	Deletions  int               `json:"deletions" yaml:"deletions"`
+	Files      []DiffFileOutput  `json:"files" yaml:"files"`
+	Errors     []RepoErrorOutput `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type DiffFileOutput struct {
+	Repo    string `json:"repo" yaml:"repo"`
+	Path    string `json:"path" yaml:"path"`
+	OldPath string `json:"old_path,omitempty" yaml:"old_path,omitempty"`
+	Added   int    `json:"added" yaml:"added"`
+	Deleted int    `json:"deleted" yaml:"deleted"`
+	Binary  bool   `json:"binary,omitempty" yaml:"binary,omitempty"`
+}
+
+func NewDiffOutput(files []git.DiffFile, failed []engine.Result, staged bool) DiffOutput {
+	output := DiffOutput{
+		Schema: SchemaID("diff"),
+		Staged: staged,
+		Total:  len(files),
+		Files:  make([]DiffFileOutput, len(files)),
+		Errors: NewRepoErrors(failed),
+	}
+
+	for i, f := range files {
+		output.Insertions += f.Added
+		output.Deletions += f.Deleted
+		output.Files[i] = DiffFileOutput{
+			Repo:    f.Repo,
+			Path:    f.Path,
+			OldPath: f.OldPath,
+			Added:   f.Added,
+			Deleted: f.Deleted,
+			Binary:  f.Binary,
+		}
+	}
+
+	return output
+}
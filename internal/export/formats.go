@@ -3,6 +3,7 @@ package export
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"gogws/internal/git"
 
@@ -10,6 +11,7 @@ import (
 )
 
 type StatusOutput struct {
+	Schema       string                   `json:"schema" yaml:"schema"`
 	Total        int                      `json:"total" yaml:"total"`
 	Clean        int                      `json:"clean" yaml:"clean"`
 	Changed      int                      `json:"changed" yaml:"changed"`
@@ -27,21 +29,34 @@ type BranchStatusOutput struct {
 }
 
 type RepositoryStatusOutput struct {
-	Path        string               `json:"path" yaml:"path"`
-	Exists      bool                 `json:"exists" yaml:"exists"`
-	Clean       bool                 `json:"clean" yaml:"clean"`
-	Branch      string               `json:"branch,omitempty" yaml:"branch,omitempty"`
-	Branches    []BranchStatusOutput `json:"branches,omitempty" yaml:"branches,omitempty"`
-	Ahead       int                  `json:"ahead" yaml:"ahead"`
-	Behind      int                  `json:"behind" yaml:"behind"`
-	Uncommitted int                  `json:"uncommitted" yaml:"uncommitted"`
-	Untracked   int                  `json:"untracked" yaml:"untracked"`
-	HasRemote   bool                 `json:"has_remote" yaml:"has_remote"`
-	Error       string               `json:"error,omitempty" yaml:"error,omitempty"`
+	Path          string               `json:"path" yaml:"path"`
+	Exists        bool                 `json:"exists" yaml:"exists"`
+	Clean         bool                 `json:"clean" yaml:"clean"`
+	Branch        string               `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Branches      []BranchStatusOutput `json:"branches,omitempty" yaml:"branches,omitempty"`
+	Ahead         int                  `json:"ahead" yaml:"ahead"`
+	Behind        int                  `json:"behind" yaml:"behind"`
+	Uncommitted   int                  `json:"uncommitted" yaml:"uncommitted"`
+	Untracked     int                  `json:"untracked" yaml:"untracked"`
+	HasRemote     bool                 `json:"has_remote" yaml:"has_remote"`
+	DefaultBranch string               `json:"default_branch,omitempty" yaml:"default_branch,omitempty"`
+	DefaultRef    string               `json:"default_ref,omitempty" yaml:"default_ref,omitempty"`
+	AheadDefault  int                  `json:"ahead_default" yaml:"ahead_default"`
+	BehindDefault int                  `json:"behind_default" yaml:"behind_default"`
+	OnDefault     bool                 `json:"on_default" yaml:"on_default"`
+	LastCommit    *CommitOutput        `json:"last_commit,omitempty" yaml:"last_commit,omitempty"`
+	Error         string               `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type CommitOutput struct {
+	Hash    string    `json:"hash" yaml:"hash"`
+	Author  string    `json:"author" yaml:"author"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Subject string    `json:"subject" yaml:"subject"`
 }
 
 func ToJSON(statuses []git.RepositoryStatus) (string, error) {
-	output := buildOutput(statuses)
+	output := NewStatusOutput(statuses)
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return "", err
@@ -50,7 +65,7 @@ func ToJSON(statuses []git.RepositoryStatus) (string, error) {
 }
 
 func ToYAML(statuses []git.RepositoryStatus) (string, error) {
-	output := buildOutput(statuses)
+	output := NewStatusOutput(statuses)
 	data, err := yaml.Marshal(output)
 	if err != nil {
 		return "", err
@@ -58,40 +73,31 @@ func ToYAML(statuses []git.RepositoryStatus) (string, error) {
 	return string(data), nil
 }
 
-func buildOutput(statuses []git.RepositoryStatus) StatusOutput {
+func DataToJSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func DataToYAML(v any) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func NewStatusOutput(statuses []git.RepositoryStatus) StatusOutput {
 	output := StatusOutput{
+		Schema:       SchemaID("status"),
 		Total:        len(statuses),
 		Repositories: make([]RepositoryStatusOutput, len(statuses)),
 	}
 
 	for i, status := range statuses {
-		repoOutput := RepositoryStatusOutput{
-			Path:        status.Path,
-			Exists:      status.Exists,
-			Clean:       status.Clean,
-			Branch:      status.Branch,
-			Ahead:       status.Ahead,
-			Behind:      status.Behind,
-			Uncommitted: status.Uncommitted,
-			Untracked:   status.Untracked,
-			HasRemote:   status.HasRemote,
-		}
-
-		if len(status.Branches) > 0 {
-			repoOutput.Branches = make([]BranchStatusOutput, len(status.Branches))
-			for j, branch := range status.Branches {
-				repoOutput.Branches[j] = BranchStatusOutput{
-					Name:      branch.Name,
-					IsCurrent: branch.IsCurrent,
-					Upstream:  branch.Upstream,
-					Ahead:     branch.Ahead,
-					Behind:    branch.Behind,
-				}
-			}
-		}
-
 		if status.Error != nil {
-			repoOutput.Error = status.Error.Error()
 			output.Errors++
 		}
 
@@ -103,12 +109,59 @@ func buildOutput(statuses []git.RepositoryStatus) StatusOutput {
 			output.Changed++
 		}
 
-		output.Repositories[i] = repoOutput
+		output.Repositories[i] = ToRepositoryOutput(status)
 	}
 
 	return output
 }
 
+func ToRepositoryOutput(status git.RepositoryStatus) RepositoryStatusOutput {
+	repoOutput := RepositoryStatusOutput{
+		Path:          status.Path,
+		Exists:        status.Exists,
+		Clean:         status.Clean,
+		Branch:        status.Branch,
+		Ahead:         status.Ahead,
+		Behind:        status.Behind,
+		Uncommitted:   status.Uncommitted,
+		Untracked:     status.Untracked,
+		HasRemote:     status.HasRemote,
+		DefaultBranch: status.DefaultBranch,
+		DefaultRef:    status.DefaultRef,
+		AheadDefault:  status.AheadDefault,
+		BehindDefault: status.BehindDefault,
+		OnDefault:     status.OnDefaultBranch(),
+	}
+
+	if status.LastCommit != nil {
+		repoOutput.LastCommit = &CommitOutput{
+			Hash:    status.LastCommit.Hash,
+			Author:  status.LastCommit.Author,
+			Date:    status.LastCommit.Date,
+			Subject: status.LastCommit.Subject,
+		}
+	}
+
+	if len(status.Branches) > 0 {
+		repoOutput.Branches = make([]BranchStatusOutput, len(status.Branches))
+		for j, branch := range status.Branches {
+			repoOutput.Branches[j] = BranchStatusOutput{
+				Name:      branch.Name,
+				IsCurrent: branch.IsCurrent,
+				Upstream:  branch.Upstream,
+				Ahead:     branch.Ahead,
+				Behind:    branch.Behind,
+			}
+		}
+	}
+
+	if status.Error != nil {
+		repoOutput.Error = status.Error.Error()
+	}
+
+	return repoOutput
+}
+
 func hasAnyBranchChanges(branches []git.BranchStatus) bool {
 	for _, b := range branches {
 		if b.Ahead > 0 || b.Behind > 0 {
@@ -119,12 +172,12 @@ func hasAnyBranchChanges(branches []git.BranchStatus) bool {
 }
 
 func Format(statuses []git.RepositoryStatus, format string) (string, error) {
-	switch format {
-	case "json":
-		return ToJSON(statuses)
-	case "yaml":
-		return ToYAML(statuses)
-	default:
-		return "", fmt.Errorf("unsupported format: %s (supported: json, yaml)", format)
+	f, err := Lookup(format)
+	if err != nil {
+		return "", err
+	}
+	if f.Status == nil {
+		return "", fmt.Errorf("format %s is not supported for status", f.Name)
 	}
+	return f.Status(statuses)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"gogws/internal/git"
 
@@ -97,6 +98,8 @@ func TestFormat(t *testing.T) {
 	}{
 		{"json format", "json", false},
 		{"yaml format", "yaml", false},
+		{"template format", "template={{.Path}}", false},
+		{"invalid template", "template={{.Path", true},
 		{"invalid format", "xml", true},
 	}
 
@@ -109,3 +112,66 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestToTemplate(t *testing.T) {
+	statuses := []git.RepositoryStatus{
+		{Path: "repo1", Exists: true, Branch: "main", Behind: 2},
+		{Path: "repo2", Exists: true, Branch: "dev"},
+	}
+
+	output, err := ToTemplate(statuses, `{{.Path}}\t{{.Branch}}\t{{.Behind}}`)
+	if err != nil {
+		t.Fatalf("ToTemplate failed: %v", err)
+	}
+
+	want := "repo1\tmain\t2\nrepo2\tdev\t0"
+	if output != want {
+		t.Errorf("ToTemplate() = %q, want %q", output, want)
+	}
+}
+
+func TestToColumns(t *testing.T) {
+	statuses := []git.RepositoryStatus{
+		{
+			Path:       "repo1",
+			Exists:     true,
+			Branch:     "main",
+			Ahead:      1,
+			LastCommit: &git.CommitInfo{Hash: "abc1234", Date: time.Now().Add(-3 * time.Hour)},
+		},
+		{Path: "repo2", Exists: false},
+		{Path: "repo3", Exists: true, Clean: true, Branch: "feature", DefaultBranch: "main", BehindDefault: 4},
+	}
+
+	columns, err := ParseColumns("path, branch,ahead,dirty,last-commit")
+	if err != nil {
+		t.Fatalf("ParseColumns failed: %v", err)
+	}
+
+	rows := ToColumns(statuses, columns)
+	want := [][]string{
+		{"repo1", "main", "1", "yes", "3 hours ago"},
+		{"repo2", "(missing)", "-", "-", "-"},
+		{"repo3", "feature", "0", "no", "-"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d", len(want), len(rows))
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+
+	defaults := ToColumns(statuses, []string{"default", "behind-default"})
+	if got := strings.Join(defaults[0], "|"); got != "-|-" {
+		t.Errorf("default columns without default branch = %q", got)
+	}
+	if got := strings.Join(defaults[2], "|"); got != "main (off)|4" {
+		t.Errorf("default columns off default = %q", got)
+	}
+
+	if _, err := ParseColumns("path,size"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
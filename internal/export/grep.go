@@ -0,0 +1,44 @@
+package export
+
+import (
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+type GrepOutput struct {
+	Schema  string            `json:"schema" yaml:"schema"`
+	Pattern string            `json:"pattern" yaml:"pattern"`
+	Total   int               `json:"total" yaml:"total"`
+	Matches []GrepMatchOutput `json:"matches" yaml:"matches"`
+	Errors  []RepoErrorOutput `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type GrepMatchOutput struct {
+	Repo   string `json:"repo" yaml:"repo"`
+	Path   string `json:"path" yaml:"path"`
+	Line   int    `json:"line" yaml:"line"`
+	Column int    `json:"column" yaml:"column"`
+	Text   string `json:"text" yaml:"text"`
+}
+
+func NewGrepOutput(pattern string, matches []git.GrepMatch, failed []engine.Result) GrepOutput {
+	output := GrepOutput{
+		Schema:  SchemaID("grep"),
+		Pattern: pattern,
+		Total:   len(matches),
+		Matches: make([]GrepMatchOutput, len(matches)),
+		Errors:  NewRepoErrors(failed),
+	}
+
+	for i, m := range matches {
+		output.Matches[i] = GrepMatchOutput{
+			Repo:   m.Repo,
+			Path:   m.Path,
+			Line:   m.Line,
+			Column: m.Column,
+			Text:   m.Text,
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"time"
+
+	"gogws/internal/git"
+)
+
+const (
+	RefTypeBranch = "branch"
+	RefTypeTag    = "tag"
+)
+
+type IncomingOutput struct {
+	Schema       string               `json:"schema" yaml:"schema"`
+	FetchedAt    time.Time            `json:"fetched_at" yaml:"fetched_at"`
+	Repositories []IncomingRepoOutput `json:"repositories" yaml:"repositories"`
+}
+
+type IncomingRepoOutput struct {
+	Path    string            `json:"path" yaml:"path"`
+	Changes []RefChangeOutput `json:"changes" yaml:"changes"`
+}
+
+type RefChangeOutput struct {
+	Ref     string         `json:"ref" yaml:"ref"`
+	Type    string         `json:"type" yaml:"type"`
+	Name    string         `json:"name" yaml:"name"`
+	Kind    string         `json:"kind" yaml:"kind"`
+	Old     string         `json:"old,omitempty" yaml:"old,omitempty"`
+	New     string         `json:"new,omitempty" yaml:"new,omitempty"`
+	Count   int            `json:"count" yaml:"count"`
+	Commits []CommitOutput `json:"commits,omitempty" yaml:"commits,omitempty"`
+}
+
+func NewIncomingOutput(repos []git.RepoRefChanges, fetchedAt time.Time) IncomingOutput {
+	output := IncomingOutput{
+		Schema:       SchemaID("incoming"),
+		FetchedAt:    fetchedAt,
+		Repositories: make([]IncomingRepoOutput, 0, len(repos)),
+	}
+
+	for _, repo := range repos {
+		output.Repositories = append(output.Repositories, IncomingRepoOutput{
+			Path:    repo.Repo,
+			Changes: ToRefChangeOutputs(repo.Changes),
+		})
+	}
+
+	return output
+}
+
+func (o *ResultsOutput) SetIncoming(repos []git.RepoRefChanges) {
+	for _, repo := range repos {
+		for i := range o.Results {
+			if o.Results[i].Path == repo.Repo {
+				o.Results[i].Incoming = ToRefChangeOutputs(repo.Changes)
+			}
+		}
+	}
+}
+
+func ToRefChangeOutputs(changes []git.RefChange) []RefChangeOutput {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	outputs := make([]RefChangeOutput, len(changes))
+	for i, c := range changes {
+		refType := RefTypeBranch
+		if c.IsTag() {
+			refType = RefTypeTag
+		}
+
+		outputs[i] = RefChangeOutput{
+			Ref:   c.Ref,
+			Type:  refType,
+			Name:  c.Name(),
+			Kind:  string(c.Kind),
+			Old:   c.Old,
+			New:   c.New,
+			Count: c.Count,
+		}
+		for _, commit := range c.Commits {
+			outputs[i].Commits = append(outputs[i].Commits, CommitOutput{
+				Hash:    commit.Hash,
+				Author:  commit.Author,
+				Date:    commit.Date,
+				Subject: commit.Subject,
+			})
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"gogws/internal/git"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+func ToJUnit(statuses []git.RepositoryStatus) (string, error) {
+	suite := junitTestSuite{Name: "gogws status", Time: junitTime(0)}
+
+	for _, repo := range NewStatusOutput(statuses).Repositories {
+		tc := junitTestCase{Name: repo.Path, Classname: "gogws.status", Time: junitTime(0)}
+
+		switch {
+		case repo.Error != "":
+			tc.Failure = &junitFailure{Message: firstLine(repo.Error), Text: repo.Error}
+			suite.Failures++
+		case !repo.Exists:
+			tc.Skipped = &junitSkipped{Message: "not cloned"}
+			suite.Skipped++
+		default:
+			tc.SystemOut = fmt.Sprintf("branch %s, ahead %d, behind %d, %d uncommitted, %d untracked",
+				repo.Branch, repo.Ahead, repo.Behind, repo.Uncommitted, repo.Untracked)
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+
+	return marshalJUnit(suite)
+}
+
+func ResultsToJUnit(output ResultsOutput) (string, error) {
+	suite := junitTestSuite{
+		Name:     "gogws " + output.Command,
+		Tests:    output.Total,
+		Failures: output.Failed,
+		Skipped:  output.Skipped,
+		Time:     junitTime(output.DurationMs),
+	}
+
+	for _, r := range output.Results {
+		tc := junitTestCase{
+			Name:      r.Path,
+			Classname: "gogws." + r.Action,
+			Time:      junitTime(r.DurationMs),
+			SystemOut: r.Stdout,
+		}
+
+		switch r.Status {
+		case ResultFailed:
+			tc.Failure = &junitFailure{Message: firstLine(r.Error), Text: r.Error}
+		case ResultSkipped:
+			tc.Skipped = &junitSkipped{Message: r.SkipReason}
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	return marshalJUnit(suite)
+}
+
+func marshalJUnit(suite junitTestSuite) (string, error) {
+	suites := junitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data), nil
+}
+
+func junitTime(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
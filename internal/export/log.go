@@ -0,0 +1,49 @@
+package export
+
+import (
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+type LogOutput struct {
+	Schema  string            `json:"schema" yaml:"schema"`
+	Remote  bool              `json:"remote" yaml:"remote"`
+	Total   int               `json:"total" yaml:"total"`
+	Commits []LogEntryOutput  `json:"commits" yaml:"commits"`
+	Errors  []RepoErrorOutput `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type LogEntryOutput struct {
+	Repo    string    `json:"repo" yaml:"repo"`
+	Hash    string    `json:"hash" yaml:"hash"`
+	Author  string    `json:"author" yaml:"author"`
+	Email   string    `json:"email" yaml:"email"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Subject string    `json:"subject" yaml:"subject"`
+}
+
+func NewLogOutput(entries []git.LogEntry, failed []engine.Result, remote bool) LogOutput {
+	output := LogOutput{
+		Schema:  SchemaID("log"),
+		Remote:  remote,
+		Total:   len(entries),
+		Commits: make([]LogEntryOutput, len(entries)),
+	}
+
+	for i, e := range entries {
+		output.Commits[i] = LogEntryOutput{
+			Repo:    e.Repo,
+			Hash:    e.Hash,
+			Author:  e.Author,
+			Email:   e.Email,
+			Date:    e.Date,
+			Subject: e.Subject,
+		}
+	}
+
+	output.Errors = NewRepoErrors(failed)
+
+	return output
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gogws/internal/gws"
+)
+
+type ManifestOutput struct {
+	Schema     string                `json:"schema" yaml:"schema"`
+	Resolved   bool                  `json:"resolved" yaml:"resolved"`
+	Projects   []ManifestEntryOutput `json:"projects" yaml:"projects"`
+	Workspaces []ManifestEntryOutput `json:"workspaces" yaml:"workspaces"`
+}
+
+type ManifestEntryOutput struct {
+	Path          string                 `json:"path" yaml:"path"`
+	DefaultBranch string                 `json:"default_branch,omitempty" yaml:"default_branch,omitempty"`
+	Origin        *ManifestOriginOutput  `json:"origin,omitempty" yaml:"origin,omitempty"`
+	Remotes       []ManifestRemoteOutput `json:"remotes" yaml:"remotes"`
+}
+
+type ManifestOriginOutput struct {
+	File string `json:"file" yaml:"file"`
+	Line int    `json:"line" yaml:"line"`
+}
+
+func (o *ManifestOriginOutput) String() string {
+	if o == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s:%d", o.File, o.Line)
+}
+
+type ManifestRemoteOutput struct {
+	Name       string   `json:"name" yaml:"name"`
+	URL        string   `json:"url" yaml:"url"`
+	Spec       string   `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Unresolved []string `json:"unresolved,omitempty" yaml:"unresolved,omitempty"`
+}
+
+func NewManifestOutput(ws *gws.Workspace, resolved bool) ManifestOutput {
+	output := ManifestOutput{
+		Schema:     SchemaID("manifest"),
+		Resolved:   resolved,
+		Projects:   make([]ManifestEntryOutput, 0, len(ws.Projects)),
+		Workspaces: make([]ManifestEntryOutput, 0, len(ws.Children)),
+	}
+
+	for _, p := range ws.Projects {
+		output.Projects = append(output.Projects, ManifestEntryOutput{
+			Path:          p.Path,
+			DefaultBranch: p.DefaultBranch,
+			Origin:        manifestOrigin(ws.Root, p.Origin),
+			Remotes:       manifestRemotes(p.Remotes, resolved),
+		})
+	}
+	for _, child := range ws.Children {
+		output.Workspaces = append(output.Workspaces, ManifestEntryOutput{
+			Path:    child.Path,
+			Remotes: manifestRemotes([]gws.Remote{child.Remote}, resolved),
+		})
+	}
+
+	return output
+}
+
+func manifestOrigin(root string, origin gws.Origin) *ManifestOriginOutput {
+	if origin.File == "" {
+		return nil
+	}
+	file := origin.File
+	if rel, err := filepath.Rel(root, file); err == nil {
+		file = rel
+	}
+	return &ManifestOriginOutput{File: filepath.ToSlash(file), Line: origin.Line}
+}
+
+func manifestRemotes(remotes []gws.Remote, resolved bool) []ManifestRemoteOutput {
+	outputs := make([]ManifestRemoteOutput, len(remotes))
+	for i, r := range remotes {
+		if !resolved {
+			outputs[i] = ManifestRemoteOutput{Name: r.Name, URL: r.Written()}
+			continue
+		}
+		outputs[i] = ManifestRemoteOutput{Name: r.Name, URL: r.URL, Spec: r.Spec, Unresolved: gws.URLVariables(r.URL)}
+	}
+	return outputs
+}
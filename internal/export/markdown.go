@@ -0,0 +1,107 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gogws/internal/git"
+)
+
+var markdownEscapes = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func ToMarkdown(statuses []git.RepositoryStatus) (string, error) {
+	output := NewStatusOutput(statuses)
+
+	var rows [][]string
+	for _, repo := range output.Repositories {
+		rows = append(rows, []string{
+			"`" + repo.Path + "`",
+			repo.Branch,
+			fmt.Sprintf("%d", repo.Ahead),
+			fmt.Sprintf("%d", repo.Behind),
+			fmt.Sprintf("%d", repo.Uncommitted),
+			fmt.Sprintf("%d", repo.Untracked),
+			repositoryState(repo),
+		})
+	}
+
+	summary := fmt.Sprintf("**Status:** %d projects, %d clean, %d changed, %d missing",
+		output.Total, output.Clean, output.Changed, output.Missing)
+	if output.Errors > 0 {
+		summary += fmt.Sprintf(", %d errors", output.Errors)
+	}
+
+	return summary + "\n\n" + markdownTable(
+		[]string{"Repository", "Branch", "Ahead", "Behind", "Uncommitted", "Untracked", "State"}, rows), nil
+}
+
+func ResultsToMarkdown(output ResultsOutput) (string, error) {
+
+	var rows [][]string
+	for _, r := range output.Results {
+		details := r.Error
+		if r.Status == ResultSkipped {
+			details = r.SkipReason
+		}
+		rows = append(rows, []string{
+			"`" + r.Path + "`",
+			resultIcon(r.Status) + " " + r.Status,
+			formatDuration(r.DurationMs),
+			details,
+		})
+	}
+
+	summary := fmt.Sprintf("**%s:** %d succeeded, %d failed, %d skipped in %s",
+		output.Command, output.Succeeded, output.Failed, output.Skipped, formatDuration(output.DurationMs))
+	if output.Stopped {
+		summary += fmt.Sprintf(" (%s)", output.StopReason)
+	}
+
+	return summary + "\n\n" + markdownTable([]string{"Repository", "Result", "Duration", "Details"}, rows), nil
+}
+
+func markdownTable(headers []string, rows [][]string) string {
+	var output strings.Builder
+
+	output.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	output.WriteString("|" + strings.Repeat(" --- |", len(headers)))
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = markdownEscapes.Replace(cell)
+		}
+		output.WriteString("\n| " + strings.Join(cells, " | ") + " |")
+	}
+
+	return output.String()
+}
+
+func repositoryState(repo RepositoryStatusOutput) string {
+	switch {
+	case repo.Error != "":
+		return "error"
+	case !repo.Exists:
+		return "missing"
+	case repo.Clean:
+		return "clean"
+	default:
+		return "changed"
+	}
+}
+
+func resultIcon(status string) string {
+	switch status {
+	case ResultSuccess:
+		return "✅"
+	case ResultFailed:
+		return "❌"
+	default:
+		return "⏭️"
+	}
+}
+
+func formatDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(10 * time.Millisecond).String()
+}
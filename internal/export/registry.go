@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gogws/internal/git"
+)
+
+const SchemaVersion = "v1"
+
+func SchemaID(command string) string {
+	return fmt.Sprintf("gogws/%s/%s", command, SchemaVersion)
+}
+
+type Formatter struct {
+	Name    string
+	Status  func(statuses []git.RepositoryStatus) (string, error)
+	Results func(output ResultsOutput) (string, error)
+	Data    func(v any) (string, error)
+}
+
+var formatters = make(map[string]Formatter)
+
+func Register(f Formatter) {
+	formatters[f.Name] = f
+}
+
+func Names() []string {
+	names := make([]string, 0, len(formatters)+1)
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append(names, TemplatePrefix+"<go-template>")
+}
+
+func IsText(format string) bool {
+	return format == "" || format == "text"
+}
+
+func Lookup(format string) (Formatter, error) {
+	if text, ok := strings.CutPrefix(format, TemplatePrefix); ok {
+		return templateFormatter(text)
+	}
+
+	f, ok := formatters[format]
+	if !ok {
+		return Formatter{}, fmt.Errorf("unsupported format: %s (supported: text, %s)", format, strings.Join(Names(), ", "))
+	}
+	return f, nil
+}
+
+func FormatResults(output ResultsOutput, format string) (string, error) {
+	f, err := Lookup(format)
+	if err != nil {
+		return "", err
+	}
+	if f.Results == nil {
+		return "", fmt.Errorf("format %s is not supported for %s", f.Name, output.Command)
+	}
+	return f.Results(output)
+}
+
+func FormatData(v any, command, format string) (string, error) {
+	f, err := Lookup(format)
+	if err != nil {
+		return "", err
+	}
+	if f.Data == nil {
+		return "", fmt.Errorf("format %s is not supported for %s (supported: json, yaml, %s<go-template>)", f.Name, command, TemplatePrefix)
+	}
+	return f.Data(v)
+}
+
+func init() {
+	Register(Formatter{Name: "json", Status: ToJSON, Results: ResultsToJSON, Data: DataToJSON})
+	Register(Formatter{Name: "yaml", Status: ToYAML, Results: ResultsToYAML, Data: DataToYAML})
+	Register(Formatter{Name: "csv", Status: ToCSV, Results: ResultsToCSV})
+	Register(Formatter{Name: "markdown", Status: ToMarkdown, Results: ResultsToMarkdown})
+	Register(Formatter{Name: "junit", Status: ToJUnit, Results: ResultsToJUnit})
+}
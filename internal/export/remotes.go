@@ -0,0 +1,121 @@
+package export
+
+import "gogws/internal/git"
+
+const (
+	RemotesTargetRepos    = "repositories"
+	RemotesTargetManifest = "manifest"
+
+	RemotesPending = "pending"
+	RemotesSynced  = "synced"
+	RemotesFailed  = "failed"
+)
+
+type RemotesSyncOutput struct {
+	Schema       string             `json:"schema" yaml:"schema"`
+	Target       string             `json:"target" yaml:"target"`
+	DryRun       bool               `json:"dry_run" yaml:"dry_run"`
+	Drifted      int                `json:"drifted" yaml:"drifted"`
+	Synced       int                `json:"synced" yaml:"synced"`
+	Failed       int                `json:"failed" yaml:"failed"`
+	Repositories []RemoteSyncResult `json:"repositories" yaml:"repositories"`
+}
+
+type RemoteSyncResult struct {
+	Path    string             `json:"path" yaml:"path"`
+	Changes []git.RemoteChange `json:"changes" yaml:"changes"`
+	Line    string             `json:"line,omitempty" yaml:"line,omitempty"`
+	State   string             `json:"state" yaml:"state"`
+	Error   string             `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func NewRemotesSyncOutput(results []RemoteSyncResult, reverse, dryRun bool) RemotesSyncOutput {
+	output := RemotesSyncOutput{
+		Schema:       SchemaID("remotes"),
+		Target:       RemotesTargetRepos,
+		DryRun:       dryRun,
+		Drifted:      len(results),
+		Repositories: results,
+	}
+	if reverse {
+		output.Target = RemotesTargetManifest
+	}
+	if output.Repositories == nil {
+		output.Repositories = []RemoteSyncResult{}
+	}
+
+	for _, r := range results {
+		switch r.State {
+		case RemotesSynced:
+			output.Synced++
+		case RemotesFailed:
+			output.Failed++
+		}
+	}
+
+	return output
+}
+
+type RemotesRewriteOutput struct {
+	Schema       string               `json:"schema" yaml:"schema"`
+	From         string               `json:"from" yaml:"from"`
+	To           string               `json:"to" yaml:"to"`
+	DryRun       bool                 `json:"dry_run" yaml:"dry_run"`
+	Manifests    []ManifestEditOutput `json:"manifests" yaml:"manifests"`
+	Skipped      []ManifestSkipOutput `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Repositories []RemoteURLRewrite   `json:"repositories" yaml:"repositories"`
+	Verified     []URLCheck           `json:"verified,omitempty" yaml:"verified,omitempty"`
+}
+
+type ManifestEditOutput struct {
+	File  string   `json:"file" yaml:"file"`
+	Line  int      `json:"line" yaml:"line"`
+	Old   string   `json:"old" yaml:"old"`
+	New   string   `json:"new" yaml:"new"`
+	URLs  []string `json:"urls" yaml:"urls"`
+	State string   `json:"state" yaml:"state"`
+	Error string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type ManifestSkipOutput struct {
+	File      string   `json:"file" yaml:"file"`
+	Line      int      `json:"line" yaml:"line"`
+	URL       string   `json:"url" yaml:"url"`
+	Resolved  string   `json:"resolved" yaml:"resolved"`
+	DefinedBy []string `json:"defined_by" yaml:"defined_by"`
+}
+
+type RemoteURLRewrite struct {
+	Path   string `json:"path" yaml:"path"`
+	Remote string `json:"remote" yaml:"remote"`
+	Old    string `json:"old" yaml:"old"`
+	New    string `json:"new" yaml:"new"`
+	State  string `json:"state" yaml:"state"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type URLCheck struct {
+	URL       string `json:"url" yaml:"url"`
+	Reachable bool   `json:"reachable" yaml:"reachable"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func NewRemotesRewriteOutput(from, to string, manifests []ManifestEditOutput, skipped []ManifestSkipOutput, repos []RemoteURLRewrite, verified []URLCheck, dryRun bool) RemotesRewriteOutput {
+	output := RemotesRewriteOutput{
+		Schema:       SchemaID("remotes-rewrite"),
+		From:         from,
+		To:           to,
+		DryRun:       dryRun,
+		Manifests:    manifests,
+		Skipped:      skipped,
+		Repositories: repos,
+		Verified:     verified,
+	}
+	if output.Manifests == nil {
+		output.Manifests = []ManifestEditOutput{}
+	}
+	if output.Repositories == nil {
+		output.Repositories = []RemoteURLRewrite{}
+	}
+	return output
+}
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gogws/internal/engine"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailed  = "failed"
+	ResultSkipped = "skipped"
+)
+
+const (
+	ActionContextKey = "action"
+	ChangeContextKey = "change"
+)
+
+type ResultsOutput struct {
+	Schema     string         `json:"schema" yaml:"schema"`
+	Command    string         `json:"command" yaml:"command"`
+	Total      int            `json:"total" yaml:"total"`
+	Succeeded  int            `json:"succeeded" yaml:"succeeded"`
+	Failed     int            `json:"failed" yaml:"failed"`
+	Skipped    int            `json:"skipped" yaml:"skipped"`
+	DurationMs int64          `json:"duration_ms" yaml:"duration_ms"`
+	Stopped    bool           `json:"stopped,omitempty" yaml:"stopped,omitempty"`
+	StopReason string         `json:"stop_reason,omitempty" yaml:"stop_reason,omitempty"`
+	Results    []ResultOutput `json:"results" yaml:"results"`
+}
+
+type ResultOutput struct {
+	Path       string            `json:"path" yaml:"path"`
+	Action     string            `json:"action" yaml:"action"`
+	Success    bool              `json:"success" yaml:"success"`
+	Status     string            `json:"status" yaml:"status"`
+	SkipReason string            `json:"skip_reason,omitempty" yaml:"skip_reason,omitempty"`
+	Error      string            `json:"error,omitempty" yaml:"error,omitempty"`
+	Stdout     string            `json:"stdout,omitempty" yaml:"stdout,omitempty"`
+	Stderr     string            `json:"stderr,omitempty" yaml:"stderr,omitempty"`
+	DurationMs int64             `json:"duration_ms" yaml:"duration_ms"`
+	Change     string            `json:"change,omitempty" yaml:"change,omitempty"`
+	Incoming   []RefChangeOutput `json:"incoming,omitempty" yaml:"incoming,omitempty"`
+}
+
+func ResultsToJSON(output ResultsOutput) (string, error) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func ResultsToYAML(output ResultsOutput) (string, error) {
+	data, err := yaml.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func NewResultsOutput(result *engine.ExecuteResult, command string) ResultsOutput {
+	output := ResultsOutput{
+		Schema:     SchemaID(command),
+		Command:    command,
+		Total:      result.TotalCount(),
+		Succeeded:  result.SuccessCount(),
+		Failed:     result.FailedCount(),
+		Skipped:    result.SkippedCount(),
+		DurationMs: result.TotalDuration.Milliseconds(),
+		Stopped:    result.Stopped,
+		StopReason: result.StopReason,
+		Results:    make([]ResultOutput, len(result.Results)),
+	}
+
+	for i, r := range result.Results {
+
+		repoOutput := ResultOutput{
+			Path:       r.Command.RepoName,
+			Action:     ResultAction(r.Command, command),
+			Success:    r.IsSuccess(),
+			Status:     ResultSuccess,
+			SkipReason: r.SkipReason,
+			Stdout:     strings.TrimSpace(r.Stdout),
+			Stderr:     strings.TrimSpace(r.Stderr),
+			DurationMs: r.Duration.Milliseconds(),
+		}
+		if v, ok := r.Command.GetContext(ChangeContextKey); ok && r.IsSuccess() {
+			repoOutput.Change, _ = v.(string)
+		}
+
+		switch {
+		case r.IsSkipped():
+			repoOutput.Status = ResultSkipped
+		case r.IsFailure():
+			repoOutput.Status = ResultFailed
+			repoOutput.Error = ResultError(r)
+		}
+
+		output.Results[i] = repoOutput
+	}
+
+	return output
+}
+
+func ResultAction(cmd engine.RepoCommand, command string) string {
+	if v, ok := cmd.GetContext(ActionContextKey); ok {
+		if action, ok := v.(string); ok {
+			return action
+		}
+	}
+	return command
+}
+
+type RepoErrorOutput struct {
+	Repo  string `json:"repo" yaml:"repo"`
+	Error string `json:"error" yaml:"error"`
+}
+
+func NewRepoErrors(failed []engine.Result) []RepoErrorOutput {
+	var errors []RepoErrorOutput
+	for _, r := range failed {
+		errors = append(errors, RepoErrorOutput{Repo: r.Command.RepoName, Error: ResultError(r)})
+	}
+	return errors
+}
+
+func ResultError(r engine.Result) string {
+	msg := strings.TrimSpace(r.Stderr)
+	if msg == "" && r.Error != nil {
+		msg = r.Error.Error()
+	}
+	if msg == "" {
+		msg = "unknown error"
+	}
+	return msg
+}
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+func testExecuteResult() *engine.ExecuteResult {
+	result := engine.NewExecuteResult()
+	result.AddResult(engine.Result{
+		Command:  engine.NewGitCommand("/ws/api", "api", "fetch"),
+		Success:  true,
+		Duration: 1500 * time.Millisecond,
+	})
+	result.AddResult(engine.Result{
+		Command:  engine.NewGitCommand("/ws/web", "web", "fetch"),
+		Error:    errors.New("exit status 128"),
+		Stderr:   "fatal: could not read from remote\n",
+		Duration: 250 * time.Millisecond,
+	})
+	result.AddResult(engine.Skip(engine.NewGitCommand("/ws/docs", "docs", "fetch"), "not cloned yet"))
+	result.TotalDuration = 2 * time.Second
+	return result
+}
+
+func TestResultsToJSON(t *testing.T) {
+	output, err := ResultsToJSON(NewResultsOutput(testExecuteResult(), "fetch"))
+	if err != nil {
+		t.Fatalf("ResultsToJSON failed: %v", err)
+	}
+
+	var result ResultsOutput
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if result.Schema != "gogws/fetch/v1" || result.Total != 3 || result.Succeeded != 1 || result.Failed != 1 || result.Skipped != 1 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	if result.Results[1].Status != ResultFailed || result.Results[1].Error != "fatal: could not read from remote" {
+		t.Errorf("unexpected failure output: %+v", result.Results[1])
+	}
+	if result.Results[2].Status != ResultSkipped || result.Results[2].SkipReason != "not cloned yet" {
+		t.Errorf("unexpected skip output: %+v", result.Results[2])
+	}
+}
+
+func TestResultsToJUnit(t *testing.T) {
+	output, err := ResultsToJUnit(NewResultsOutput(testExecuteResult(), "fetch"))
+	if err != nil {
+		t.Fatalf("ResultsToJUnit failed: %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Fatalf("Failed to unmarshal XML: %v", err)
+	}
+
+	suite := suites.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("unexpected suite counts: tests=%d failures=%d skipped=%d", suite.Tests, suite.Failures, suite.Skipped)
+	}
+	if suite.Cases[0].Time != "1.500" {
+		t.Errorf("expected time 1.500, got %s", suite.Cases[0].Time)
+	}
+	if suite.Cases[1].Failure == nil || !strings.Contains(suite.Cases[1].Failure.Text, "could not read") {
+		t.Errorf("expected failure with stderr, got %+v", suite.Cases[1].Failure)
+	}
+	if suite.Cases[2].Skipped == nil {
+		t.Error("expected skipped testcase")
+	}
+}
+
+func TestResultsToCSVAndMarkdown(t *testing.T) {
+	csvOutput, err := ResultsToCSV(NewResultsOutput(testExecuteResult(), "fetch"))
+	if err != nil {
+		t.Fatalf("ResultsToCSV failed: %v", err)
+	}
+	lines := strings.Split(csvOutput, "\n")
+	if len(lines) != 4 || lines[1] != "api,fetch,success,1500,," {
+		t.Errorf("unexpected CSV output:\n%s", csvOutput)
+	}
+
+	mdOutput, err := ResultsToMarkdown(NewResultsOutput(testExecuteResult(), "fetch"))
+	if err != nil {
+		t.Fatalf("ResultsToMarkdown failed: %v", err)
+	}
+	if !strings.Contains(mdOutput, "| `docs` | ⏭️ skipped | 0s | not cloned yet |") {
+		t.Errorf("unexpected Markdown output:\n%s", mdOutput)
+	}
+}
+
+func TestResultsSetIncoming(t *testing.T) {
+	output := NewResultsOutput(testExecuteResult(), "fetch")
+	output.SetIncoming([]git.RepoRefChanges{{
+		Repo:    "api",
+		Changes: []git.RefChange{{Ref: "refs/tags/v1.0", Kind: git.RefNew, New: "ccc"}},
+	}})
+
+	if got := output.Results[0].Incoming; len(got) != 1 || got[0].Name != "v1.0" || got[0].Type != RefTypeTag {
+		t.Errorf("unexpected incoming for api: %+v", got)
+	}
+	if got := output.Results[1].Incoming; got != nil {
+		t.Errorf("expected no incoming for web, got %+v", got)
+	}
+}
+
+func TestFormatResultsUnknown(t *testing.T) {
+	if _, err := FormatResults(NewResultsOutput(testExecuteResult(), "fetch"), "xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	for _, command := range SchemaCommands() {
+		schema, err := Schema(command)
+		if err != nil {
+			t.Fatalf("Schema(%s) failed: %v", command, err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+			t.Fatalf("schema for %s is not valid JSON: %v", command, err)
+		}
+		if !strings.Contains(schema, SchemaID(command)) {
+			t.Errorf("schema for %s does not mention %s", command, SchemaID(command))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var schemaNames = map[string]string{
+	"status":          "status",
+	"fetch":           "results",
+	"ff":              "results",
+	"update":          "results",
+	"clone":           "results",
+	"commit":          "results",
+	"check":           "check",
+	"init":            "init",
+	"events":          "events",
+	"watch":           "watch",
+	"workspace":       "workspace",
+	"job":             "job",
+	"log":             "log",
+	"incoming":        "incoming",
+	"diff":            "diff",
+	"grep":            "grep",
+	"apply":           "apply",
+	"branches":        "branches",
+	"remotes":         "remotes",
+	"remotes-rewrite": "remotes-rewrite",
+	"manifest":        "manifest",
+}
+
+func SchemaCommands() []string {
+	commands := make([]string, 0, len(schemaNames))
+	for command := range schemaNames {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+func Schema(command string) (string, error) {
+	name, ok := schemaNames[command]
+	if !ok {
+		return "", fmt.Errorf("no schema for command: %s (available: %s)", command, strings.Join(SchemaCommands(), ", "))
+	}
+
+	data, err := schemaFiles.ReadFile(fmt.Sprintf("schemas/%s.%s.json", name, SchemaVersion))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
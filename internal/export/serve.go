@@ -0,0 +1,99 @@
+package export
+
+import (
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+const (
+	JobQueued   = "queued"
+	JobRunning  = "running"
+	JobFinished = "finished"
+)
+
+type WorkspaceOutput struct {
+	Schema     string            `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Path       string            `json:"path" yaml:"path"`
+	Name       string            `json:"name" yaml:"name"`
+	Exists     bool              `json:"exists" yaml:"exists"`
+	Remote     *RemoteOutput     `json:"remote,omitempty" yaml:"remote,omitempty"`
+	Error      string            `json:"error,omitempty" yaml:"error,omitempty"`
+	Projects   []ProjectOutput   `json:"projects" yaml:"projects"`
+	Workspaces []WorkspaceOutput `json:"workspaces" yaml:"workspaces"`
+}
+
+type ProjectOutput struct {
+	Path    string         `json:"path" yaml:"path"`
+	Exists  bool           `json:"exists" yaml:"exists"`
+	Remotes []RemoteOutput `json:"remotes" yaml:"remotes"`
+}
+
+type JobOutput struct {
+	Schema     string         `json:"schema" yaml:"schema"`
+	ID         string         `json:"id" yaml:"id"`
+	Command    string         `json:"command" yaml:"command"`
+	State      string         `json:"state" yaml:"state"`
+	CreatedAt  time.Time      `json:"created_at" yaml:"created_at"`
+	StartedAt  *time.Time     `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty" yaml:"finished_at,omitempty"`
+	ExitCode   *int           `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	Error      string         `json:"error,omitempty" yaml:"error,omitempty"`
+	Result     *ResultsOutput `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+func NewWorkspaceOutput(ws *gws.Workspace) WorkspaceOutput {
+	output := toWorkspaceOutput(ws)
+	output.Schema = SchemaID("workspace")
+	return output
+}
+
+func toWorkspaceOutput(ws *gws.Workspace) WorkspaceOutput {
+	output := WorkspaceOutput{
+		Path:       ws.Path,
+		Name:       ws.Name,
+		Exists:     ws.Exists,
+		Projects:   make([]ProjectOutput, len(ws.Projects)),
+		Workspaces: make([]WorkspaceOutput, len(ws.Children)),
+	}
+	if ws.Remote.URL != "" {
+		output.Remote = &RemoteOutput{Name: ws.Remote.Name, URL: ws.Remote.URL}
+	}
+	if ws.Error != nil {
+		output.Error = ws.Error.Error()
+	}
+
+	for i, p := range ws.Projects {
+		remotes := make([]RemoteOutput, len(p.Remotes))
+		for j, r := range p.Remotes {
+			remotes[j] = RemoteOutput{Name: r.Name, URL: r.URL}
+		}
+		output.Projects[i] = ProjectOutput{Path: p.Path, Exists: p.Exists, Remotes: remotes}
+	}
+
+	for i, child := range ws.Children {
+		output.Workspaces[i] = toWorkspaceOutput(child)
+	}
+
+	return output
+}
+
+func NewJobOutput(id, command string, createdAt time.Time) JobOutput {
+	return JobOutput{Schema: SchemaID("job"), ID: id, Command: command, State: JobQueued, CreatedAt: createdAt}
+}
+
+func (j *JobOutput) Finish(result *engine.ExecuteResult, incoming []git.RepoRefChanges, code int, err error, now time.Time) {
+	j.State = JobFinished
+	j.FinishedAt = &now
+	j.ExitCode = &code
+	if err != nil {
+		j.Error = err.Error()
+	}
+	if result != nil {
+		output := NewResultsOutput(result, j.Command)
+		output.SetIncoming(incoming)
+		j.Result = &output
+	}
+}
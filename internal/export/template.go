@@ -0,0 +1,110 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"gogws/internal/git"
+)
+
+const TemplatePrefix = "template="
+
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"ago":   func(t time.Time) string { return RelativeTime(t, time.Now()) },
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+}
+
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(templateEscapes.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func templateFormatter(text string) (Formatter, error) {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return Formatter{}, err
+	}
+
+	return Formatter{
+		Name: "template",
+		Status: func(statuses []git.RepositoryStatus) (string, error) {
+			return executeTemplate(tmpl, NewStatusOutput(statuses).Repositories, func(r RepositoryStatusOutput) string { return r.Path })
+		},
+		Results: func(output ResultsOutput) (string, error) {
+			return executeTemplate(tmpl, output.Results, func(r ResultOutput) string { return r.Path })
+		},
+		Data: func(v any) (string, error) {
+			var output strings.Builder
+			if err := tmpl.Execute(&output, v); err != nil {
+				return "", fmt.Errorf("failed to render template: %w", err)
+			}
+			return output.String(), nil
+		},
+	}, nil
+}
+
+func ToTemplate(statuses []git.RepositoryStatus, text string) (string, error) {
+	f, err := templateFormatter(text)
+	if err != nil {
+		return "", err
+	}
+	return f.Status(statuses)
+}
+
+func executeTemplate[T any](tmpl *template.Template, items []T, name func(T) string) (string, error) {
+	var output strings.Builder
+	for _, item := range items {
+		if err := tmpl.Execute(&output, item); err != nil {
+			return "", fmt.Errorf("failed to render template for %s: %w", name(item), err)
+		}
+		output.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(output.String(), "\n"), nil
+}
+
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		d = 0
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour") + " ago"
+	case d < 30*24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day") + " ago"
+	case d < 365*24*time.Hour:
+		return plural(int(d/(30*24*time.Hour)), "month") + " ago"
+	default:
+		return plural(int(d/(365*24*time.Hour)), "year") + " ago"
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
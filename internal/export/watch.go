@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/json"
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+const (
+	WatchEventStatus = "status"
+	WatchEventFetch  = "fetch"
+)
+
+type WatchEventOutput struct {
+	Schema     string                  `json:"schema"`
+	Time       time.Time               `json:"time"`
+	Event      string                  `json:"event"`
+	Repository *RepositoryStatusOutput `json:"repository,omitempty"`
+	Succeeded  *int                    `json:"succeeded,omitempty"`
+	Failed     *int                    `json:"failed,omitempty"`
+}
+
+func WatchStatusLine(status git.RepositoryStatus, now time.Time) (string, error) {
+	repo := ToRepositoryOutput(status)
+	return watchLine(WatchEventOutput{Event: WatchEventStatus, Repository: &repo}, now)
+}
+
+func WatchFetchLine(result *engine.ExecuteResult, now time.Time) (string, error) {
+	succeeded, failed := result.SuccessCount(), result.FailedCount()
+	return watchLine(WatchEventOutput{Event: WatchEventFetch, Succeeded: &succeeded, Failed: &failed}, now)
+}
+
+func watchLine(event WatchEventOutput, now time.Time) (string, error) {
+	event.Schema = SchemaID("watch")
+	event.Time = now
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,194 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+type ApplyOutcome string
+
+const (
+	ApplyClean         ApplyOutcome = "applied"
+	ApplyConflicts     ApplyOutcome = "conflicts"
+	ApplyNotApplicable ApplyOutcome = "not-applicable"
+	ApplySkipped       ApplyOutcome = "skipped"
+	ApplyFailed        ApplyOutcome = "failed"
+)
+
+type ApplyResult struct {
+	Repo      string       `json:"repo"`
+	Outcome   ApplyOutcome `json:"outcome"`
+	Branch    string       `json:"branch,omitempty"`
+	Commit    string       `json:"commit,omitempty"`
+	Conflicts []string     `json:"conflicts,omitempty"`
+	Reason    string       `json:"reason,omitempty"`
+}
+
+type SourceCommit struct {
+	Repo    string
+	Hash    string
+	Author  string
+	Message string
+}
+
+func run(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return string(output), errors.New(msg)
+		}
+		return string(output), err
+	}
+	return string(output), nil
+}
+
+func ResolveCommit(repoPath, commitish string) (*SourceCommit, error) {
+	output, err := run(repoPath, "log", "-1", "--format=%H%x00%an <%ae>%x00%B", commitish, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", commitish, err)
+	}
+
+	parts := strings.SplitN(output, "\x00", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("failed to resolve %s", commitish)
+	}
+
+	return &SourceCommit{
+		Repo:    repoPath,
+		Hash:    parts[0],
+		Author:  parts[1],
+		Message: strings.TrimSpace(parts[2]),
+	}, nil
+}
+
+func FormatPatch(c *SourceCommit) ([]byte, error) {
+	output, err := run(c.Repo, "format-patch", "-1", "--full-index", "--stdout", c.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create patch from %s: %w", c.Hash, err)
+	}
+	return []byte(output), nil
+}
+
+func SeedBlobs(c *SourceCommit, repoPath string) error {
+	output, err := run(c.Repo, "diff-tree", "-r", "--no-commit-id", c.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || strings.Trim(fields[2], "0") == "" {
+			continue
+		}
+
+		blob := exec.Command("git", "cat-file", "blob", fields[2])
+		blob.Dir = c.Repo
+		data, err := blob.Output()
+		if err != nil {
+			return fmt.Errorf("failed to read blob %s: %w", fields[2], err)
+		}
+
+		store := exec.Command("git", "hash-object", "-w", "--stdin")
+		store.Dir = repoPath
+		store.Stdin = bytes.NewReader(data)
+		if err := store.Run(); err != nil {
+			return fmt.Errorf("failed to store blob %s: %w", fields[2], err)
+		}
+	}
+
+	return nil
+}
+
+var patchSubject = regexp.MustCompile(`(?m)^Subject: (?:\[[^\]]*\] *)?(.+)$`)
+
+func PatchSubject(patch []byte) string {
+	m := patchSubject.FindSubmatch(patch)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(string(m[1]))
+}
+
+func CheckPatch(repoPath, patchPath string) error {
+	_, err := run(repoPath, "apply", "--check", patchPath)
+	return applyError(err)
+}
+
+func ApplyPatch(repoPath, patchPath string) (ApplyOutcome, []string, error) {
+	_, applyErr := run(repoPath, "apply", "--3way", patchPath)
+	if applyErr == nil {
+		return ApplyClean, nil, nil
+	}
+
+	output, err := run(repoPath, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return ApplyFailed, nil, fmt.Errorf("failed to list conflicts: %w", err)
+	}
+	if conflicts := strings.Fields(output); len(conflicts) > 0 {
+		return ApplyConflicts, conflicts, nil
+	}
+
+	return ApplyNotApplicable, nil, applyError(applyErr)
+}
+
+func applyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	lines := strings.Split(err.Error(), "\n")
+	return errors.New(strings.TrimPrefix(lines[len(lines)-1], "error: "))
+}
+
+func CurrentBranch(repoPath string) (string, error) {
+	output, err := run(repoPath, "symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil || strings.TrimSpace(output) == "" {
+		output, err = run(repoPath, "rev-parse", "HEAD")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current branch: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+func CreateBranch(repoPath, name string) error {
+	if _, err := run(repoPath, "checkout", "-q", "-b", name); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+	return nil
+}
+
+func DropBranch(repoPath, name, restore string) error {
+	if _, err := run(repoPath, "checkout", "-q", restore); err != nil {
+		return fmt.Errorf("failed to switch back to %s: %w", restore, err)
+	}
+	if _, err := run(repoPath, "branch", "-D", name); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", name, err)
+	}
+	return nil
+}
+
+func CommitIndex(repoPath, message, author string) (string, error) {
+	args := []string{"commit", "-q", "-m", message}
+	if author != "" {
+		args = append(args, "--author", author)
+	}
+	if _, err := run(repoPath, args...); err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+
+	output, err := run(repoPath, "rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
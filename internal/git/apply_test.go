@@ -0,0 +1,16 @@
+package git
+
+import "testing"
+
+func TestPatchSubject(t *testing.T) {
+	tests := map[string]string{
+		"From abc Mon Sep 17 00:00:00 2001\nSubject: [PATCH 2/3] Fix license header\n\n---\n": "Fix license header",
+		"Subject: Bump Go\n":   "Bump Go",
+		"diff --git a/x b/x\n": "",
+	}
+	for patch, want := range tests {
+		if got := PatchSubject([]byte(patch)); got != want {
+			t.Errorf("PatchSubject(%q) = %q, want %q", patch, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+const DefaultChangeTrailer = "Workspace-Change"
+
+func HasChangesToCommit(repoPath string, all bool) (bool, error) {
+	staged, err := differs(repoPath, "diff", "--cached", "--quiet")
+	if err != nil || staged || !all {
+		return staged, err
+	}
+	return differs(repoPath, "diff", "--quiet")
+}
+
+func differs(repoPath string, args ...string) (bool, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("failed to check for changes: %w", err)
+	}
+}
+
+func CommitArgs(message, trailer, changeID string, all bool) []string {
+	args := []string{"commit", "-m", message, "--trailer", trailer + ": " + changeID}
+	if all {
+		args = append(args, "--all")
+	}
+	return args
+}
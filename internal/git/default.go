@@ -0,0 +1,86 @@
+package git
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func DefaultBranch(repoPath, override string) (name, ref string) {
+	remotes := listRemotes(repoPath)
+
+	if override != "" {
+		for _, remote := range remotes {
+			if refExists(repoPath, "refs/remotes/"+remote+"/"+override) {
+				return override, remote + "/" + override
+			}
+		}
+		return override, override
+	}
+
+	for _, remote := range remotes {
+		output, err := run(repoPath, "symbolic-ref", "--short", "-q", "refs/remotes/"+remote+"/HEAD")
+		if err != nil {
+			continue
+		}
+		ref = strings.TrimSpace(output)
+		return strings.TrimPrefix(ref, remote+"/"), ref
+	}
+
+	for _, candidate := range []string{"main", "master"} {
+		for _, remote := range remotes {
+			if refExists(repoPath, "refs/remotes/"+remote+"/"+candidate) {
+				return candidate, remote + "/" + candidate
+			}
+		}
+		if refExists(repoPath, "refs/heads/"+candidate) {
+			return candidate, candidate
+		}
+	}
+
+	return "", ""
+}
+
+func SetRemoteHeads(repoPath, override string) {
+	if _, ref := DefaultBranch(repoPath, override); ref != "" {
+		return
+	}
+	for _, remote := range listRemotes(repoPath) {
+		if _, err := run(repoPath, "remote", "set-head", remote, "--auto"); err == nil {
+			return
+		}
+	}
+}
+
+func addDefaultBranch(status *RepositoryStatus, repoPath, override string) {
+	status.DefaultBranch, status.DefaultRef = DefaultBranch(repoPath, override)
+	if status.DefaultRef == "" {
+		return
+	}
+
+	output, err := run(repoPath, "rev-list", "--left-right", "--count", "HEAD..."+status.DefaultRef)
+	if err != nil {
+		return
+	}
+	if parts := strings.Fields(output); len(parts) == 2 {
+		status.AheadDefault, _ = strconv.Atoi(parts[0])
+		status.BehindDefault, _ = strconv.Atoi(parts[1])
+	}
+}
+
+func listRemotes(repoPath string) []string {
+	output, err := run(repoPath, "remote")
+	if err != nil {
+		return nil
+	}
+	remotes := strings.Fields(output)
+	if i := slices.Index(remotes, "origin"); i > 0 {
+		remotes[0], remotes[i] = remotes[i], remotes[0]
+	}
+	return remotes
+}
+
+func refExists(repoPath, ref string) bool {
+	_, err := run(repoPath, "rev-parse", "--verify", "-q", ref)
+	return err == nil
+}
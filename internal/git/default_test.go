@@ -0,0 +1,55 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func gitTest(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func cloneWithBranch(t *testing.T, branch string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	upstream := filepath.Join(root, "upstream")
+	gitTest(t, root, "init", "-q", "-b", branch, upstream)
+	gitTest(t, upstream, "commit", "-q", "--allow-empty", "-m", "initial")
+
+	clone := filepath.Join(root, "clone")
+	gitTest(t, root, "clone", "-q", upstream, clone)
+	gitTest(t, clone, "remote", "set-head", "origin", "-d")
+	return clone
+}
+
+func TestSetRemoteHeadsSkipsResolvableDefault(t *testing.T) {
+	clone := cloneWithBranch(t, "main")
+
+	SetRemoteHeads(clone, "")
+	if refExists(clone, "refs/remotes/origin/HEAD") {
+		t.Error("set-head should not run when origin/main already resolves the default branch")
+	}
+}
+
+func TestSetRemoteHeadsWhenDefaultUnknown(t *testing.T) {
+	clone := cloneWithBranch(t, "develop")
+
+	if name, ref := DefaultBranch(clone, ""); ref != "" {
+		t.Fatalf("expected no local default, got %s (%s)", name, ref)
+	}
+
+	SetRemoteHeads(clone, "")
+	if name, ref := DefaultBranch(clone, ""); name != "develop" || ref != "origin/develop" {
+		t.Errorf("expected origin/develop after set-head, got %s (%s)", name, ref)
+	}
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+)
+
+type DiffFile struct {
+	Repo    string
+	Path    string
+	OldPath string
+	Added   int
+	Deleted int
+	Binary  bool
+}
+
+func DiffArgs(repo string, staged bool) []string {
+	args := []string{"diff", "--no-color", "--no-ext-diff",
+		"--src-prefix=a/" + repo + "/", "--dst-prefix=b/" + repo + "/"}
+	if staged {
+		args = append(args, "--cached")
+	}
+	return args
+}
+
+func DiffNumstatArgs(staged bool) []string {
+	args := []string{"diff", "--numstat", "-z", "--no-ext-diff"}
+	if staged {
+		args = append(args, "--cached")
+	}
+	return args
+}
+
+func ParseNumstat(repo, output string) []DiffFile {
+	var files []DiffFile
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		added, rest, ok := strings.Cut(fields[i], "\t")
+		if !ok {
+			continue
+		}
+		deleted, path, ok := strings.Cut(rest, "\t")
+		if !ok {
+			continue
+		}
+
+		file := DiffFile{Repo: repo, Path: path}
+		if path == "" && i+2 < len(fields) {
+			file.OldPath, file.Path = fields[i+1], fields[i+2]
+			i += 2
+		}
+
+		if added == "-" && deleted == "-" {
+			file.Binary = true
+		} else {
+			file.Added, _ = strconv.Atoi(added)
+			file.Deleted, _ = strconv.Atoi(deleted)
+		}
+
+		files = append(files, file)
+	}
+	return files
+}
@@ -0,0 +1,22 @@
+package git
+
+import "testing"
+
+func TestParseNumstat(t *testing.T) {
+	output := "3\t1\tsrc/main.go\x00-\t-\tlogo.png\x000\t2\t\x00old/name.go\x00new/name.go\x00"
+
+	files := ParseNumstat("api", output)
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %+v", files)
+	}
+
+	if f := files[0]; f.Repo != "api" || f.Path != "src/main.go" || f.Added != 3 || f.Deleted != 1 || f.Binary {
+		t.Errorf("unexpected file: %+v", f)
+	}
+	if f := files[1]; f.Path != "logo.png" || !f.Binary {
+		t.Errorf("expected binary file, got %+v", f)
+	}
+	if f := files[2]; f.Path != "new/name.go" || f.OldPath != "old/name.go" || f.Deleted != 2 {
+		t.Errorf("unexpected rename: %+v", f)
+	}
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+type GrepOptions struct {
+	Pattern      string
+	IgnoreCase   bool
+	WordRegexp   bool
+	FixedStrings bool
+	Pathspecs    []string
+}
+
+type GrepMatch struct {
+	Repo   string
+	Path   string
+	Line   int
+	Column int
+	Text   string
+}
+
+func GrepArgs(opts GrepOptions) []string {
+	args := []string{"grep", "-n", "--column", "-z", "-I", "--no-color"}
+	if opts.IgnoreCase {
+		args = append(args, "-i")
+	}
+	if opts.WordRegexp {
+		args = append(args, "-w")
+	}
+	if opts.FixedStrings {
+		args = append(args, "-F")
+	}
+	args = append(args, "-e", opts.Pattern)
+	if len(opts.Pathspecs) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Pathspecs...)
+	}
+	return args
+}
+
+func Grep(repoPath string, opts GrepOptions) (string, error) {
+	cmd := exec.Command("git", GrepArgs(opts)...)
+	cmd.Dir = repoPath
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && stderr.Len() == 0 {
+			return "", nil
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git grep failed: %s", msg)
+		}
+		return "", fmt.Errorf("git grep failed: %w", err)
+	}
+
+	return string(output), nil
+}
+
+func ParseGrep(repo, output string) []GrepMatch {
+	var matches []GrepMatch
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\x00", 4)
+		if len(parts) != 4 {
+			continue
+		}
+
+		lineNo, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		column, _ := strconv.Atoi(parts[2])
+
+		matches = append(matches, GrepMatch{
+			Repo:   repo,
+			Path:   parts[0],
+			Line:   lineNo,
+			Column: column,
+			Text:   parts[3],
+		})
+	}
+	return matches
+}
@@ -0,0 +1,31 @@
+package git
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrepArgs(t *testing.T) {
+	args := GrepArgs(GrepOptions{Pattern: "-v", IgnoreCase: true, Pathspecs: []string{"*.go"}})
+	want := []string{"grep", "-n", "--column", "-z", "-I", "--no-color", "-i", "-e", "-v", "--", "*.go"}
+	if !slices.Equal(args, want) {
+		t.Errorf("got %v, want %v", args, want)
+	}
+}
+
+func TestParseGrep(t *testing.T) {
+	output := "src/client.go\x0012\x005\x00func NewOldClient() {\n" +
+		"README.md\x003\x001\x00OldClient: a\x00b\n" +
+		"garbage\n"
+
+	matches := ParseGrep("api", output)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+	if m := matches[0]; m.Repo != "api" || m.Path != "src/client.go" || m.Line != 12 || m.Column != 5 || m.Text != "func NewOldClient() {" {
+		t.Errorf("unexpected match: %+v", m)
+	}
+	if m := matches[1]; m.Text != "OldClient: a\x00b" {
+		t.Errorf("text should keep everything after the third separator, got %q", m.Text)
+	}
+}
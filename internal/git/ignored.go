@@ -0,0 +1,35 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func IgnoredDirs(repoPath string) ([]string, error) {
+	cmd := exec.Command("git", "ls-files", "--others", "--ignored", "--exclude-standard", "--directory", "-z")
+	cmd.Dir = repoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+
+	return parseIgnoredDirs(string(output)), nil
+}
+
+func parseIgnoredDirs(output string) []string {
+	dirs := []string{}
+	for _, entry := range strings.Split(output, "\x00") {
+		if dir, ok := strings.CutSuffix(entry, "/"); ok && dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func IsIgnored(repoPath, path string) bool {
+	cmd := exec.Command("git", "check-ignore", "-q", path)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
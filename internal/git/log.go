@@ -0,0 +1,110 @@
+package git
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const logFormat = "--format=%H%x00%an%x00%ae%x00%ct%x00%s"
+
+type LogEntry struct {
+	Repo    string
+	Hash    string
+	Author  string
+	Email   string
+	Date    time.Time
+	Subject string
+}
+
+type LogOptions struct {
+	Since  string
+	Author string
+	Grep   string
+	Change string
+	Remote bool
+	Limit  int
+}
+
+func LogArgs(opts LogOptions) []string {
+	args := []string{"log", logFormat, "--no-color"}
+	if opts.Limit > 0 {
+		args = append(args, "-n", strconv.Itoa(opts.Limit))
+	}
+	if opts.Since != "" {
+		args = append(args, "--since="+opts.Since)
+	}
+	if opts.Author != "" {
+		args = append(args, "--author="+opts.Author)
+	}
+	if opts.Grep != "" {
+		args = append(args, "--grep="+opts.Grep, "--regexp-ignore-case")
+	}
+	if opts.Change != "" {
+		args = append(args, "--grep="+opts.Change, "--fixed-strings")
+	}
+	switch {
+	case opts.Remote:
+		args = append(args, "--remotes", "--not", "--branches")
+	case opts.Change != "":
+		args = append(args, "--branches", "--remotes")
+	}
+	return args
+}
+
+func ParseLog(repo, output string) []LogEntry {
+	var entries []LogEntry
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\x00", 5)
+		if len(parts) != 5 {
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, LogEntry{
+			Repo:    repo,
+			Hash:    parts[0],
+			Author:  parts[1],
+			Email:   parts[2],
+			Date:    time.Unix(timestamp, 0),
+			Subject: parts[4],
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.After(entries[j].Date)
+	})
+	return entries
+}
+
+func MergeLogs(logs [][]LogEntry) []LogEntry {
+	switch len(logs) {
+	case 0:
+		return nil
+	case 1:
+		return logs[0]
+	}
+
+	mid := len(logs) / 2
+	return mergeTwo(MergeLogs(logs[:mid]), MergeLogs(logs[mid:]))
+}
+
+func mergeTwo(a, b []LogEntry) []LogEntry {
+	merged := make([]LogEntry, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if b[0].Date.After(a[0].Date) {
+			merged = append(merged, b[0])
+			b = b[1:]
+		} else {
+			merged = append(merged, a[0])
+			a = a[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseLog(t *testing.T) {
+	output := "aaa\x00Alice\x00alice@example.com\x00100\x00First\n" +
+		"bbb\x00Bob\x00bob@example.com\x00300\x00Second: with \x00 separator\n" +
+		"garbage\n"
+
+	entries := ParseLog("api", output)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Hash != "bbb" || entries[1].Hash != "aaa" {
+		t.Errorf("entries should be newest first, got %s, %s", entries[0].Hash, entries[1].Hash)
+	}
+	if entries[0].Repo != "api" || entries[0].Author != "Bob" || !entries[0].Date.Equal(time.Unix(300, 0)) {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+	if entries[0].Subject != "Second: with \x00 separator" {
+		t.Errorf("subject should keep everything after the fourth separator, got %q", entries[0].Subject)
+	}
+}
+
+func TestMergeLogs(t *testing.T) {
+	entry := func(repo string, ts int64) LogEntry {
+		return LogEntry{Repo: repo, Date: time.Unix(ts, 0)}
+	}
+
+	merged := MergeLogs([][]LogEntry{
+		{entry("a", 9), entry("a", 5), entry("a", 1)},
+		{entry("b", 8), entry("b", 5)},
+		nil,
+		{entry("c", 10), entry("c", 2)},
+	})
+
+	want := []string{"c:10", "a:9", "b:8", "a:5", "b:5", "c:2", "a:1"}
+	if len(merged) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(merged))
+	}
+	for i, e := range merged {
+		got := fmt.Sprintf("%s:%d", e.Repo, e.Date.Unix())
+		if got != want[i] {
+			t.Errorf("entry %d: got %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestLogArgsChange(t *testing.T) {
+	args := LogArgs(LogOptions{Change: "abc-123"})
+	want := []string{"log", logFormat, "--no-color", "--grep=abc-123", "--fixed-strings", "--branches", "--remotes"}
+	if fmt.Sprint(args) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", args, want)
+	}
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PruneMerged = "merged"
+	PruneGone   = "gone"
+	PruneStale  = "stale"
+)
+
+type PruneOptions struct {
+	Default   string
+	Merged    bool
+	Gone      bool
+	StaleDays int
+	Now       time.Time
+}
+
+type PruneCandidate struct {
+	Repo        string    `json:"repo"`
+	Branch      string    `json:"branch"`
+	Reasons     []string  `json:"reasons"`
+	CommittedAt time.Time `json:"committed_at"`
+	Unpushed    int       `json:"unpushed"`
+	Keep        string    `json:"keep,omitempty"`
+}
+
+func FindPruneCandidates(repoPath, repo string, opts PruneOptions) ([]PruneCandidate, error) {
+	branches, err := GetBranches(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	defaultName, defaultRef := DefaultBranch(repoPath, opts.Default)
+
+	merged := make(map[string]bool)
+	if opts.Merged && defaultRef != "" {
+		output, err := run(repoPath, "for-each-ref", "--format=%(refname:short)", "--merged="+defaultRef, "refs/heads/")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list merged branches: %w", err)
+		}
+		for _, name := range strings.Fields(output) {
+			merged[name] = true
+		}
+	}
+
+	var staleBefore time.Time
+	if opts.StaleDays > 0 {
+		staleBefore = opts.Now.AddDate(0, 0, -opts.StaleDays)
+	}
+
+	var candidates []PruneCandidate
+	for _, b := range branches {
+		if b.Name == defaultName {
+			continue
+		}
+
+		var reasons []string
+		if merged[b.Name] {
+			reasons = append(reasons, PruneMerged)
+		}
+		if opts.Gone && b.UpstreamGone {
+			reasons = append(reasons, PruneGone)
+		}
+		if !staleBefore.IsZero() && !b.CommittedAt.IsZero() && b.CommittedAt.Before(staleBefore) {
+			reasons = append(reasons, PruneStale)
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		candidate := PruneCandidate{
+			Repo:        repo,
+			Branch:      b.Name,
+			Reasons:     reasons,
+			CommittedAt: b.CommittedAt,
+		}
+		unpushed, err := unpushedCommits(repoPath, b.Name)
+		candidate.Unpushed = unpushed
+		switch {
+		case b.IsCurrent:
+			candidate.Keep = "current branch"
+		case err != nil:
+			candidate.Keep = "unpushed commits unknown"
+		case unpushed > 0:
+			candidate.Keep = "unpushed commits"
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+func unpushedCommits(repoPath, branch string) (int, error) {
+	output, err := run(repoPath, "rev-list", "--count", "refs/heads/"+branch, "--not", "--remotes")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
+func DeleteBranch(repoPath, branch string) error {
+	if _, err := run(repoPath, "branch", "-D", branch); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", branch, err)
+	}
+	return nil
+}
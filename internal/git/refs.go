@@ -0,0 +1,145 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RefChangeKind string
+
+const (
+	RefNew     RefChangeKind = "new"
+	RefUpdated RefChangeKind = "updated"
+	RefForced  RefChangeKind = "forced"
+	RefDeleted RefChangeKind = "deleted"
+)
+
+const (
+	remoteRefPrefix = "refs/remotes/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+type RefChange struct {
+	Ref     string        `json:"ref"`
+	Kind    RefChangeKind `json:"kind"`
+	Old     string        `json:"old,omitempty"`
+	New     string        `json:"new,omitempty"`
+	Count   int           `json:"count"`
+	Commits []CommitInfo  `json:"commits,omitempty"`
+}
+
+type RepoRefChanges struct {
+	Repo    string      `json:"repo"`
+	Changes []RefChange `json:"changes"`
+}
+
+func (c RefChange) IsTag() bool {
+	return strings.HasPrefix(c.Ref, tagRefPrefix)
+}
+
+func (c RefChange) Name() string {
+	if c.IsTag() {
+		return strings.TrimPrefix(c.Ref, tagRefPrefix)
+	}
+	return strings.TrimPrefix(c.Ref, remoteRefPrefix)
+}
+
+func SnapshotRefs(repoPath string) (map[string]string, error) {
+	output, err := run(repoPath, "for-each-ref", "--format=%(objectname) %(refname)", remoteRefPrefix, tagRefPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+
+	refs := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		sha, ref, ok := strings.Cut(line, " ")
+		if !ok || strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+		refs[ref] = sha
+	}
+	return refs, nil
+}
+
+func DiffRefs(repoPath string, before, after map[string]string, defaultRef string, maxCommits int) []RefChange {
+	var changes []RefChange
+
+	for ref, newSHA := range after {
+		oldSHA, existed := before[ref]
+		switch {
+		case !existed:
+			change := RefChange{Ref: ref, Kind: RefNew, New: newSHA}
+			if !change.IsTag() && defaultRef != "" {
+				change.Count, change.Commits = commitsBetween(repoPath, defaultRef, newSHA, maxCommits)
+			}
+			changes = append(changes, change)
+		case oldSHA != newSHA:
+			change := RefChange{Ref: ref, Kind: RefUpdated, Old: oldSHA, New: newSHA}
+			if !isAncestor(repoPath, oldSHA, newSHA) {
+				change.Kind = RefForced
+			}
+			if !change.IsTag() {
+				change.Count, change.Commits = commitsBetween(repoPath, oldSHA, newSHA, maxCommits)
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	for ref, oldSHA := range before {
+		if _, ok := after[ref]; !ok {
+			changes = append(changes, RefChange{Ref: ref, Kind: RefDeleted, Old: oldSHA})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Ref < changes[j].Ref
+	})
+	return changes
+}
+
+func isAncestor(repoPath, ancestor, descendant string) bool {
+	_, err := run(repoPath, "merge-base", "--is-ancestor", ancestor, descendant)
+	return err == nil
+}
+
+func commitsBetween(repoPath, from, to string, limit int) (int, []CommitInfo) {
+	rangeSpec := from + ".." + to
+
+	output, err := run(repoPath, "rev-list", "--count", rangeSpec)
+	if err != nil {
+		return 0, nil
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(output))
+
+	if limit <= 0 || count == 0 {
+		return count, nil
+	}
+
+	output, err = run(repoPath, "log", "-n", strconv.Itoa(limit), "--format=%h%x00%an%x00%ct%x00%s", rangeSpec)
+	if err != nil {
+		return count, nil
+	}
+
+	var commits []CommitInfo
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		parts := strings.SplitN(line, "\x00", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, CommitInfo{
+			Hash:    parts[0],
+			Author:  parts[1],
+			Date:    time.Unix(timestamp, 0),
+			Subject: parts[3],
+		})
+	}
+
+	return count, commits
+}
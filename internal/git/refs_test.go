@@ -0,0 +1,61 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffRefsNewAndDeleted(t *testing.T) {
+	before := map[string]string{
+		"refs/remotes/origin/main": "aaa",
+		"refs/remotes/origin/old":  "bbb",
+	}
+	after := map[string]string{
+		"refs/remotes/origin/main": "aaa",
+		"refs/tags/v1.0":           "ccc",
+	}
+
+	changes := DiffRefs(t.TempDir(), before, after, "", 10)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+
+	if c := changes[0]; c.Ref != "refs/remotes/origin/old" || c.Kind != RefDeleted || c.Old != "bbb" || c.Name() != "origin/old" || c.IsTag() {
+		t.Errorf("unexpected deleted change: %+v", c)
+	}
+	if c := changes[1]; c.Ref != "refs/tags/v1.0" || c.Kind != RefNew || c.New != "ccc" || c.Name() != "v1.0" || !c.IsTag() {
+		t.Errorf("unexpected new change: %+v", c)
+	}
+}
+
+func TestDiffRefsNewBranchCommits(t *testing.T) {
+	clone := cloneWithBranch(t, "main")
+	upstream := filepath.Join(filepath.Dir(clone), "upstream")
+
+	before, err := SnapshotRefs(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitTest(t, upstream, "checkout", "-q", "-b", "feature")
+	gitTest(t, upstream, "commit", "-q", "--allow-empty", "-m", "first feature commit")
+	gitTest(t, upstream, "commit", "-q", "--allow-empty", "-m", "second feature commit")
+	gitTest(t, clone, "fetch", "-q", "origin")
+
+	after, err := SnapshotRefs(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := DiffRefs(clone, before, after, "origin/main", 1)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %+v", changes)
+	}
+	c := changes[0]
+	if c.Ref != "refs/remotes/origin/feature" || c.Kind != RefNew || c.Count != 2 {
+		t.Errorf("unexpected new branch change: %+v", c)
+	}
+	if len(c.Commits) != 1 || c.Commits[0].Subject != "second feature commit" {
+		t.Errorf("expected the newest commit within the limit, got %+v", c.Commits)
+	}
+}
@@ -0,0 +1,148 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+const lsRemoteTimeout = 30 * time.Second
+
+type RemoteAction string
+
+const (
+	RemoteAdd    RemoteAction = "add"
+	RemoteSetURL RemoteAction = "set-url"
+	RemoteRename RemoteAction = "rename"
+	RemoteRemove RemoteAction = "remove"
+)
+
+type RemoteChange struct {
+	Action  RemoteAction `json:"action"`
+	Name    string       `json:"name"`
+	OldName string       `json:"old_name,omitempty"`
+	URL     string       `json:"url,omitempty"`
+	OldURL  string       `json:"old_url,omitempty"`
+}
+
+func (c RemoteChange) String() string {
+	switch c.Action {
+	case RemoteAdd:
+		return fmt.Sprintf("add %s %s", c.Name, c.URL)
+	case RemoteSetURL:
+		return fmt.Sprintf("set-url %s %s -> %s", c.Name, c.OldURL, c.URL)
+	case RemoteRename:
+		return fmt.Sprintf("rename %s -> %s", c.OldName, c.Name)
+	case RemoteRemove:
+		return fmt.Sprintf("remove %s %s", c.Name, c.OldURL)
+	default:
+		return string(c.Action)
+	}
+}
+
+func GetRemotes(repoPath string) ([]Remote, error) {
+	remotes, err := getRemotesExec(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	sort.Slice(remotes, func(i, j int) bool { return remotes[i].Name < remotes[j].Name })
+	return remotes, nil
+}
+
+func CompareRemotes(expected, actual []Remote) []RemoteChange {
+	actualByName := make(map[string]Remote, len(actual))
+	for _, r := range actual {
+		actualByName[r.Name] = r
+	}
+	expectedNames := make(map[string]bool, len(expected))
+	for _, r := range expected {
+		expectedNames[r.Name] = true
+	}
+
+	renamed := make(map[string]bool)
+	var changes []RemoteChange
+
+	for _, want := range expected {
+		if have, ok := actualByName[want.Name]; ok {
+			if have.URL != want.URL {
+				changes = append(changes, RemoteChange{Action: RemoteSetURL, Name: want.Name, URL: want.URL, OldURL: have.URL})
+			}
+			continue
+		}
+
+		if old, ok := findRenamed(actual, want.URL, expectedNames, renamed); ok {
+			renamed[old.Name] = true
+			changes = append(changes, RemoteChange{Action: RemoteRename, Name: want.Name, OldName: old.Name, URL: want.URL})
+			continue
+		}
+
+		changes = append(changes, RemoteChange{Action: RemoteAdd, Name: want.Name, URL: want.URL})
+	}
+
+	for _, have := range actual {
+		if !expectedNames[have.Name] && !renamed[have.Name] {
+			changes = append(changes, RemoteChange{Action: RemoteRemove, Name: have.Name, OldURL: have.URL})
+		}
+	}
+
+	return changes
+}
+
+func findRenamed(actual []Remote, url string, expectedNames, renamed map[string]bool) (Remote, bool) {
+	for _, r := range actual {
+		if r.URL == url && !expectedNames[r.Name] && !renamed[r.Name] {
+			return r, true
+		}
+	}
+	return Remote{}, false
+}
+
+func ApplyRemoteChange(repoPath string, change RemoteChange) error {
+	var args []string
+	switch change.Action {
+	case RemoteAdd:
+		args = []string{"remote", "add", change.Name, change.URL}
+	case RemoteSetURL:
+		args = []string{"remote", "set-url", change.Name, change.URL}
+	case RemoteRename:
+		args = []string{"remote", "rename", change.OldName, change.Name}
+	case RemoteRemove:
+		args = []string{"remote", "remove", change.Name}
+	default:
+		return fmt.Errorf("unknown remote action %q", change.Action)
+	}
+
+	if _, err := run(repoPath, args...); err != nil {
+		return fmt.Errorf("git remote %s %s: %w", change.Action, change.Name, err)
+	}
+	return nil
+}
+
+func LsRemote(url string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), lsRemoteTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", url, "HEAD")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("timed out after %s", lsRemoteTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			lines := strings.Split(msg, "\n")
+			return errors.New(lines[0])
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareRemotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []Remote
+		actual   []Remote
+		want     []RemoteChange
+	}{
+		{
+			name:     "in sync",
+			expected: []Remote{{Name: "origin", URL: "git@example.com:api.git"}},
+			actual:   []Remote{{Name: "origin", URL: "git@example.com:api.git"}},
+		},
+		{
+			name:     "set-url, rename, add, then remove",
+			expected: []Remote{{Name: "origin", URL: "git@new.example.com:team/api.git"}, {Name: "upstream", URL: "git@example.com:upstream/api.git"}, {Name: "mirror", URL: "git@mirror.example.com:api.git"}},
+			actual:   []Remote{{Name: "fork", URL: "git@example.com:upstream/api.git"}, {Name: "old", URL: "git@example.com:old/api.git"}, {Name: "origin", URL: "git@old.example.com:team/api.git"}},
+			want: []RemoteChange{
+				{Action: RemoteSetURL, Name: "origin", URL: "git@new.example.com:team/api.git", OldURL: "git@old.example.com:team/api.git"},
+				{Action: RemoteRename, Name: "upstream", OldName: "fork", URL: "git@example.com:upstream/api.git"},
+				{Action: RemoteAdd, Name: "mirror", URL: "git@mirror.example.com:api.git"},
+				{Action: RemoteRemove, Name: "old", OldURL: "git@example.com:old/api.git"},
+			},
+		},
+		{
+			name:     "rename keeps the remote instead of removing it",
+			expected: []Remote{{Name: "origin", URL: "git@example.com:api.git"}},
+			actual:   []Remote{{Name: "github", URL: "git@example.com:api.git"}},
+			want:     []RemoteChange{{Action: RemoteRename, Name: "origin", OldName: "github", URL: "git@example.com:api.git"}},
+		},
+		{
+			name:     "expected name is never renamed away",
+			expected: []Remote{{Name: "origin", URL: "git@example.com:a.git"}, {Name: "upstream", URL: "git@example.com:b.git"}},
+			actual:   []Remote{{Name: "origin", URL: "git@example.com:b.git"}, {Name: "fork", URL: "git@example.com:a.git"}},
+			want: []RemoteChange{
+				{Action: RemoteSetURL, Name: "origin", URL: "git@example.com:a.git", OldURL: "git@example.com:b.git"},
+				{Action: RemoteAdd, Name: "upstream", URL: "git@example.com:b.git"},
+				{Action: RemoteRemove, Name: "fork", OldURL: "git@example.com:a.git"},
+			},
+		},
+		{
+			name:     "one rename per URL",
+			expected: []Remote{{Name: "origin", URL: "git@example.com:api.git"}},
+			actual:   []Remote{{Name: "a", URL: "git@example.com:api.git"}, {Name: "b", URL: "git@example.com:api.git"}},
+			want: []RemoteChange{
+				{Action: RemoteRename, Name: "origin", OldName: "a", URL: "git@example.com:api.git"},
+				{Action: RemoteRemove, Name: "b", OldURL: "git@example.com:api.git"},
+			},
+		},
+		{
+			name:   "remove all",
+			actual: []Remote{{Name: "origin", URL: "git@example.com:api.git"}},
+			want:   []RemoteChange{{Action: RemoteRemove, Name: "origin", OldURL: "git@example.com:api.git"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareRemotes(tt.expected, tt.actual); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareRemotes() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,12 +7,21 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func GetStatus(repoPath string) RepositoryStatus {
 	return getStatusExec(repoPath)
 }
 
+func GetStatusWithDefault(repoPath, defaultBranch string) RepositoryStatus {
+	status := getStatusExec(repoPath)
+	if status.Exists && status.Error == nil {
+		addDefaultBranch(&status, repoPath, defaultBranch)
+	}
+	return status
+}
+
 func GetStatusDetailed(repoPath string) RepositoryStatus {
 	return getStatusExecDetailed(repoPath)
 }
@@ -66,7 +75,9 @@ func getStatusExec(repoPath string) RepositoryStatus {
 		status.Clean = uncommitted == 0 && untracked == 0
 	}
 
-	branches, err := getBranches(repoPath)
+	status.LastCommit = getLastCommit(repoPath)
+
+	branches, err := GetBranches(repoPath)
 	if err == nil {
 		status.Branches = branches
 		for _, b := range branches {
@@ -81,9 +92,9 @@ func getStatusExec(repoPath string) RepositoryStatus {
 	return status
 }
 
-func getBranches(repoPath string) ([]BranchStatus, error) {
+func GetBranches(repoPath string) ([]BranchStatus, error) {
 	cmd := exec.Command("git", "for-each-ref",
-		"--format=%(refname:short)|%(upstream:short)|%(HEAD)",
+		"--format=%(refname:short)|%(upstream:short)|%(HEAD)|%(upstream:track)|%(committerdate:unix)",
 		"refs/heads/")
 	cmd.Dir = repoPath
 	output, err := cmd.Output()
@@ -101,7 +112,7 @@ func getBranches(repoPath string) ([]BranchStatus, error) {
 		}
 
 		parts := strings.Split(line, "|")
-		if len(parts) < 3 {
+		if len(parts) < 5 {
 			continue
 		}
 
@@ -110,12 +121,16 @@ func getBranches(repoPath string) ([]BranchStatus, error) {
 		isCurrent := parts[2] == "*"
 
 		branch := BranchStatus{
-			Name:      branchName,
-			IsCurrent: isCurrent,
-			Upstream:  upstream,
+			Name:         branchName,
+			IsCurrent:    isCurrent,
+			Upstream:     upstream,
+			UpstreamGone: parts[3] == "[gone]",
+		}
+		if timestamp, err := strconv.ParseInt(parts[4], 10, 64); err == nil {
+			branch.CommittedAt = time.Unix(timestamp, 0)
 		}
 
-		if upstream != "" {
+		if upstream != "" && !branch.UpstreamGone {
 			ahead, behind := getAheadBehind(repoPath, branchName, upstream)
 			branch.Ahead = ahead
 			branch.Behind = behind
@@ -192,10 +207,38 @@ func getStatusExecDetailed(repoPath string) RepositoryStatus {
 		}
 	}
 
-	branches, err := getBranches(repoPath)
+	status.LastCommit = getLastCommit(repoPath)
+
+	branches, err := GetBranches(repoPath)
 	if err == nil {
 		status.Branches = branches
 	}
 
 	return status
 }
+
+func getLastCommit(repoPath string) *CommitInfo {
+	cmd := exec.Command("git", "log", "-1", "--format=%h%x00%an%x00%ct%x00%s")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimRight(string(output), "\n"), "\x00", 4)
+	if len(parts) != 4 {
+		return nil
+	}
+
+	timestamp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &CommitInfo{
+		Hash:    parts[0],
+		Author:  parts[1],
+		Date:    time.Unix(timestamp, 0),
+		Subject: parts[3],
+	}
+}
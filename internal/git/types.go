@@ -1,23 +1,44 @@
 package git
 
+import "time"
+
 type BranchStatus struct {
-	Name      string `json:"name"`
-	IsCurrent bool   `json:"is_current"`
-	Upstream  string `json:"upstream,omitempty"`
-	Ahead     int    `json:"ahead"`
-	Behind    int    `json:"behind"`
+	Name         string    `json:"name"`
+	IsCurrent    bool      `json:"is_current"`
+	Upstream     string    `json:"upstream,omitempty"`
+	UpstreamGone bool      `json:"upstream_gone,omitempty"`
+	Ahead        int       `json:"ahead"`
+	Behind       int       `json:"behind"`
+	CommittedAt  time.Time `json:"committed_at"`
 }
 
 type RepositoryStatus struct {
-	Path        string         `json:"path"`
-	Exists      bool           `json:"exists"`
-	Clean       bool           `json:"clean"`
-	Branch      string         `json:"branch"`
-	Branches    []BranchStatus `json:"branches,omitempty"`
-	Ahead       int            `json:"ahead"`
-	Behind      int            `json:"behind"`
-	Uncommitted int            `json:"uncommitted"`
-	Untracked   int            `json:"untracked"`
-	HasRemote   bool           `json:"has_remote"`
-	Error       error          `json:"-"`
+	Path          string         `json:"path"`
+	Exists        bool           `json:"exists"`
+	Clean         bool           `json:"clean"`
+	Branch        string         `json:"branch"`
+	Branches      []BranchStatus `json:"branches,omitempty"`
+	Ahead         int            `json:"ahead"`
+	Behind        int            `json:"behind"`
+	Uncommitted   int            `json:"uncommitted"`
+	Untracked     int            `json:"untracked"`
+	HasRemote     bool           `json:"has_remote"`
+	DefaultBranch string         `json:"default_branch,omitempty"`
+	DefaultRef    string         `json:"default_ref,omitempty"`
+	AheadDefault  int            `json:"ahead_default"`
+	BehindDefault int            `json:"behind_default"`
+	LastCommit    *CommitInfo    `json:"last_commit,omitempty"`
+	Error         error          `json:"-"`
+	Cached        bool           `json:"-"`
+}
+
+func (s RepositoryStatus) OnDefaultBranch() bool {
+	return s.DefaultBranch == "" || s.Branch == s.DefaultBranch
+}
+
+type CommitInfo struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
 }
@@ -10,6 +10,7 @@ const DefaultTemplate = `# GWS Workspace - ignore all sub-projects and workspace
 # Track config directory
 !{{.ConfigDir}}/
 !{{.ConfigDir}}/**
+{{.ConfigDir}}/cache/
 
 # Track legacy files at root
 !{{.ProjectsFile}}
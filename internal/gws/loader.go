@@ -80,9 +80,11 @@ func (l *Loader) loadRecursive(root string, depth int) (*Workspace, error) {
 		} else {
 			for _, p := range projects {
 				project := Project{
-					Path:    p.Path,
-					Remotes: p.Remotes,
-					Exists:  false,
+					Path:          p.Path,
+					Remotes:       resolveRemotes(p.Path, p.Remotes),
+					DefaultBranch: p.DefaultBranch,
+					Origin:        p.Origin,
+					Exists:        false,
 				}
 
 				projectPath := filepath.Join(absRoot, p.Path)
@@ -112,7 +114,7 @@ func (l *Loader) loadRecursive(root string, depth int) (*Workspace, error) {
 				child := &Workspace{
 					Path:     childRef.Path,
 					Name:     childRef.Name,
-					Remote:   childRef.Remote,
+					Remote:   resolveRemote(childRef.Path, childRef.Remote),
 					Exists:   false,
 					Projects: []Project{},
 					Children: []*Workspace{},
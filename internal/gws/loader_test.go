@@ -137,6 +137,31 @@ func TestLoader_Load(t *testing.T) {
 	}
 }
 
+func TestLoader_LoadDefaultBranch(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectsFile := filepath.Join(tempDir, ProjectsFileName)
+	content := "project1 | git@github.com:user/repo1.git | default=develop\nproject2 | git@github.com:user/repo2.git"
+	if err := os.WriteFile(projectsFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := New(tempDir).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(ws.Projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(ws.Projects))
+	}
+	if ws.Projects[0].DefaultBranch != "develop" {
+		t.Errorf("Expected default branch develop, got %q", ws.Projects[0].DefaultBranch)
+	}
+	if ws.Projects[1].DefaultBranch != "" {
+		t.Errorf("Expected no default branch, got %q", ws.Projects[1].DefaultBranch)
+	}
+}
+
 func TestLoader_LoadRecursive(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -2,10 +2,14 @@ package gws
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -91,14 +95,28 @@ func parseProjectsFile(root string) ([]Project, error) {
 		return nil, fmt.Errorf("no projects file found")
 	}
 
-	projectsPath := location.Path
+	projects, err := parseProjectsFileAt(location.Path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ignorePatterns, err := parseIgnoreFile(root)
+	if err == nil && len(ignorePatterns) > 0 {
+		projects = filterIgnoredProjects(projects, ignorePatterns)
+	}
+
+	return projects, nil
+}
 
+func parseProjectsFileAt(projectsPath, prefix string, stack []string) ([]Project, error) {
 	file, err := os.Open(projectsPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open projects file: %w", err)
 	}
 	defer file.Close()
 
+	stack = append(stack, projectsPath)
+
 	var projects []Project
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
@@ -115,24 +133,95 @@ func parseProjectsFile(root string) ([]Project, error) {
 			line = strings.TrimSpace(line[:idx])
 		}
 
+		if isIncludeLine(line) {
+			include, err := parseIncludeLine(projectsPath, line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", projectsPath, lineNum, err)
+			}
+			if slices.Contains(stack, include.Path) {
+				return nil, fmt.Errorf("%s:%d: include cycle: %s", projectsPath, lineNum,
+					strings.Join(append(stack, include.Path), " -> "))
+			}
+
+			included, err := parseProjectsFileAt(include.Path, path.Join(prefix, include.Prefix), stack)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return nil, fmt.Errorf("%s:%d: include %s: file not found", projectsPath, lineNum, include.Path)
+				}
+				return nil, err
+			}
+			for _, project := range included {
+				projects = mergeProject(projects, project)
+			}
+			continue
+		}
+
 		project, err := parseProjectLine(line)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing line %d: %w", lineNum, err)
+			return nil, fmt.Errorf("%s:%d: %w", projectsPath, lineNum, err)
+		}
+		if prefix != "" {
+			project.Path = path.Join(prefix, project.Path)
 		}
+		project.Origin = Origin{File: projectsPath, Line: lineNum, Prefix: prefix}
 
-		projects = append(projects, project)
+		projects = mergeProject(projects, project)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading projects file: %w", err)
+		return nil, fmt.Errorf("error reading %s: %w", projectsPath, err)
 	}
 
-	ignorePatterns, err := parseIgnoreFile(root)
-	if err == nil && len(ignorePatterns) > 0 {
-		projects = filterIgnoredProjects(projects, ignorePatterns)
+	return projects, nil
+}
+
+type includeDirective struct {
+	Path   string
+	Prefix string
+}
+
+func isIncludeLine(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) > 0 && fields[0] == includeKeyword && !strings.Contains(line, "|")
+}
+
+func parseIncludeLine(from, line string) (includeDirective, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return includeDirective{}, fmt.Errorf("include requires a file path")
 	}
 
-	return projects, nil
+	target := fields[1]
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "git@") {
+		return includeDirective{}, fmt.Errorf("include %s: only local files can be included", target)
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(from), target)
+	}
+
+	include := includeDirective{Path: filepath.Clean(target)}
+	for _, option := range fields[2:] {
+		value, ok := strings.CutPrefix(option, includePrefixOption)
+		if !ok {
+			return includeDirective{}, fmt.Errorf("include %s: unknown option %q", fields[1], option)
+		}
+		if value == "" || path.IsAbs(value) || strings.HasPrefix(path.Clean(value), "..") {
+			return includeDirective{}, fmt.Errorf("include %s: invalid prefix %q", fields[1], value)
+		}
+		include.Prefix = path.Clean(value)
+	}
+	return include, nil
+}
+
+func mergeProject(projects []Project, project Project) []Project {
+	i := slices.IndexFunc(projects, func(p Project) bool { return p.Path == project.Path })
+	if i < 0 {
+		return append(projects, project)
+	}
+	slog.Debug("Project overridden by a later definition",
+		"path", project.Path, "previous", projects[i].Origin.String(), "origin", project.Origin.String())
+	projects[i] = project
+	return projects
 }
 
 func parseWorkspacesFile(root string) ([]*Workspace, error) {
@@ -212,6 +301,13 @@ func parseIgnoreFile(root string) ([]string, error) {
 	return patterns, nil
 }
 
+const defaultBranchPrefix = "default="
+
+const (
+	includeKeyword      = "include"
+	includePrefixOption = "prefix="
+)
+
 func parseProjectLine(line string) (Project, error) {
 	parts := strings.Split(line, "|")
 	if len(parts) < 2 {
@@ -234,6 +330,14 @@ func parseProjectLine(line string) (Project, error) {
 			continue
 		}
 
+		if branch, ok := strings.CutPrefix(remotePart, defaultBranchPrefix); ok {
+			if branch == "" || strings.ContainsAny(branch, " \t") {
+				return Project{}, fmt.Errorf("invalid default branch for project %s: %q", path, branch)
+			}
+			project.DefaultBranch = branch
+			continue
+		}
+
 		remote, err := parseRemote(remotePart, i-1)
 		if err != nil {
 			return Project{}, err
@@ -0,0 +1,115 @@
+package gws
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseProjectLineDefaultBranch(t *testing.T) {
+	project, err := parseProjectLine("libs/core | git@example.com:core.git | git@example.com:fork.git fork | default=develop")
+	if err != nil {
+		t.Fatalf("parseProjectLine failed: %v", err)
+	}
+	if project.DefaultBranch != "develop" {
+		t.Errorf("DefaultBranch = %q, want develop", project.DefaultBranch)
+	}
+	if len(project.Remotes) != 2 || project.Remotes[1].Name != "fork" {
+		t.Errorf("unexpected remotes: %+v", project.Remotes)
+	}
+	if got := FormatProjectLine(project); got != "libs/core | git@example.com:core.git | git@example.com:fork.git fork | default=develop" {
+		t.Errorf("FormatProjectLine = %q", got)
+	}
+
+	if _, err := parseProjectLine("libs/core | git@example.com:core.git | default="); err == nil {
+		t.Error("expected error for empty default branch")
+	}
+}
+
+func TestExpandURL(t *testing.T) {
+	vars := map[string]string{"GIT_BASE": "git@github.com:company"}
+	lookup := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+
+	got, err := ExpandURL("${GIT_BASE}/api.git", lookup)
+	if err != nil || got != "git@github.com:company/api.git" {
+		t.Errorf("ExpandURL = %q, %v", got, err)
+	}
+
+	got, err = ExpandURL("${MIRROR}/api.git", lookup)
+	if err == nil || got != "${MIRROR}/api.git" {
+		t.Errorf("expected undefined variable error, got %q, %v", got, err)
+	}
+	if names := URLVariables(got); len(names) != 1 || names[0] != "MIRROR" {
+		t.Errorf("URLVariables = %v", names)
+	}
+
+	if got, _ := ExpandURL("https://host/$HOME/x.git", lookup); got != "https://host/$HOME/x.git" {
+		t.Errorf("bare $NAME must be left alone, got %q", got)
+	}
+}
+
+func TestParseProjectsFileIncludes(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sharedFile := filepath.Join(tempDir, "shared", "core.gws")
+	if err := os.MkdirAll(filepath.Dir(sharedFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sharedFile, []byte("lib | git@example.com:lib.git\ntools | git@example.com:tools.git\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	projectsFile := filepath.Join(tempDir, ProjectsFileName)
+	content := "include shared/core.gws prefix=core\napp | git@example.com:app.git\ncore/tools | git@example.com:fork/tools.git\n"
+	if err := os.WriteFile(projectsFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := parseProjectsFile(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		path, url string
+		origin    Origin
+	}{
+		{"core/lib", "git@example.com:lib.git", Origin{File: sharedFile, Line: 1, Prefix: "core"}},
+		{"core/tools", "git@example.com:fork/tools.git", Origin{File: projectsFile, Line: 3}},
+		{"app", "git@example.com:app.git", Origin{File: projectsFile, Line: 2}},
+	}
+	if len(projects) != len(want) {
+		t.Fatalf("got %d projects, want %d: %+v", len(projects), len(want), projects)
+	}
+	for i, w := range want {
+		p := projects[i]
+		if p.Path != w.path || p.Remotes[0].URL != w.url || p.Origin != w.origin {
+			t.Errorf("project %d = %s %s %+v, want %s %s %+v", i, p.Path, p.Remotes[0].URL, p.Origin, w.path, w.url, w.origin)
+		}
+	}
+}
+
+func TestParseProjectsFileIncludeCycle(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectsFile := filepath.Join(tempDir, ProjectsFileName)
+	if err := os.WriteFile(projectsFile, []byte("app | url\ninclude other.gws\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	otherFile := filepath.Join(tempDir, "other.gws")
+	if err := os.WriteFile(otherFile, []byte("# shared\ninclude "+ProjectsFileName+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := parseProjectsFile(tempDir)
+	if err == nil {
+		t.Fatal("expected include cycle error")
+	}
+	if !strings.HasPrefix(err.Error(), otherFile+":2: include cycle") {
+		t.Errorf("error = %q, want it to name %s:2", err, otherFile)
+	}
+}
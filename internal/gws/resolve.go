@@ -0,0 +1,65 @@
+package gws
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+var urlVariablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+var urlResolver func(url string) (string, error)
+
+func SetURLResolver(resolve func(url string) (string, error)) {
+	urlResolver = resolve
+}
+
+func ExpandURL(url string, lookup func(name string) (string, bool)) (string, error) {
+	var missing []string
+	expanded := urlVariablePattern.ReplaceAllStringFunc(url, func(match string) string {
+		name := urlVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := lookup(name); ok {
+			return value
+		}
+		missing = append(missing, name)
+		return match
+	})
+	if len(missing) > 0 {
+		return expanded, fmt.Errorf("undefined variable(s) %s in %s", strings.Join(missing, ", "), url)
+	}
+	return expanded, nil
+}
+
+func URLVariables(url string) []string {
+	var names []string
+	for _, m := range urlVariablePattern.FindAllStringSubmatch(url, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+func resolveRemotes(path string, remotes []Remote) []Remote {
+	if urlResolver == nil {
+		return remotes
+	}
+	resolved := make([]Remote, len(remotes))
+	for i, r := range remotes {
+		resolved[i] = resolveRemote(path, r)
+	}
+	return resolved
+}
+
+func resolveRemote(path string, r Remote) Remote {
+	if urlResolver == nil {
+		return r
+	}
+	url, err := urlResolver(r.URL)
+	if err != nil {
+		slog.Warn("Failed to resolve remote URL", "path", path, "remote", r.Name, "err", err)
+	}
+	if url != r.URL {
+		r.Spec, r.URL = r.URL, url
+	}
+	return r
+}
@@ -0,0 +1,133 @@
+package gws
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+type ManifestEdit struct {
+	File string
+	Line int
+	Old  string
+	New  string
+	URLs []string
+}
+
+type ManifestSkip struct {
+	File string
+	Line int
+	Spec string
+	URL  string
+}
+
+func ManifestFiles(ws *Workspace) []string {
+	var files []string
+	if ws.Root != "" {
+		if location := getProjectsFileLocation(ws.Root); location != nil {
+			files = appendIncludedFiles(files, location.Path)
+		}
+		if location := getWorkspacesFileLocation(ws.Root); location != nil {
+			files = append(files, location.Path)
+		}
+	}
+	for _, child := range ws.Children {
+		for _, file := range ManifestFiles(child) {
+			if !slices.Contains(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+func appendIncludedFiles(files []string, filePath string) []string {
+	if slices.Contains(files, filePath) {
+		return files
+	}
+	files = append(files, filePath)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return files
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if idx := strings.Index(line, "#"); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if !isIncludeLine(line) {
+			continue
+		}
+		if include, err := parseIncludeLine(filePath, line); err == nil {
+			files = appendIncludedFiles(files, include.Path)
+		}
+	}
+	return files
+}
+
+func RewriteManifestURLs(filePath string, rewrite, resolve func(url string) string, write bool) ([]ManifestEdit, []ManifestSkip, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var edits []ManifestEdit
+	var skipped []ManifestSkip
+	for i, line := range lines {
+		rewritten, urls, kept := rewriteLineURLs(line, rewrite, resolve)
+		for _, spec := range kept {
+			skipped = append(skipped, ManifestSkip{File: filePath, Line: i + 1, Spec: spec, URL: resolve(spec)})
+		}
+		if rewritten != line {
+			edits = append(edits, ManifestEdit{File: filePath, Line: i + 1, Old: line, New: rewritten, URLs: urls})
+			lines[i] = rewritten
+		}
+	}
+
+	if write && len(edits) > 0 {
+		if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+	}
+	return edits, skipped, nil
+}
+
+func rewriteLineURLs(line string, rewrite, resolve func(url string) string) (string, []string, []string) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return line, nil, nil
+	}
+
+	content, comment := line, ""
+	if idx := strings.Index(line, "#"); idx != -1 {
+		content, comment = line[:idx], line[idx:]
+	}
+
+	var urls, kept []string
+	parts := strings.Split(content, "|")
+	for i := 1; i < len(parts); i++ {
+		start := len(parts[i]) - len(strings.TrimLeft(parts[i], " \t"))
+		end := start + strings.IndexAny(parts[i][start:]+" ", " \t")
+		spec := parts[i][start:end]
+		if spec == "" || strings.HasPrefix(spec, defaultBranchPrefix) {
+			continue
+		}
+
+		url := resolve(spec)
+		target := rewrite(url)
+		if target == url {
+			continue
+		}
+		if rewritten := rewrite(spec); rewritten != spec && resolve(rewritten) == target {
+			parts[i] = parts[i][:start] + rewritten + parts[i][end:]
+			urls = append(urls, target)
+			continue
+		}
+		kept = append(kept, spec)
+	}
+
+	return strings.Join(parts, "|") + comment, urls, kept
+}
@@ -1,9 +1,15 @@
 package gws
 
+import (
+	"fmt"
+	"slices"
+)
+
 const (
 	FileExtension      = "gws"
 	ConfigDirName      = ".gws"
 	HooksDirName       = "hooks"
+	HooksFileName      = "hooks.yaml"
 	TemplatesDirName   = "templates"
 	ProjectsFileName   = ".projects." + FileExtension
 	WorkspacesFileName = ".workspaces." + FileExtension
@@ -15,12 +21,35 @@ const (
 type Remote struct {
 	Name string
 	URL  string
+	Spec string
+}
+
+func (r Remote) Written() string {
+	if r.Spec != "" {
+		return r.Spec
+	}
+	return r.URL
+}
+
+type Origin struct {
+	File   string
+	Line   int
+	Prefix string
+}
+
+func (o Origin) String() string {
+	if o.File == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", o.File, o.Line)
 }
 
 type Project struct {
-	Path    string
-	Remotes []Remote
-	Exists  bool
+	Path          string
+	Remotes       []Remote
+	DefaultBranch string
+	Origin        Origin
+	Exists        bool
 }
 
 type Workspace struct {
@@ -43,6 +72,22 @@ func (w *Workspace) AllProjects() []Project {
 	return all
 }
 
+func SelectProjects(projects []Project, paths []string) ([]Project, error) {
+	if len(paths) == 0 {
+		return projects, nil
+	}
+
+	selected := make([]Project, 0, len(paths))
+	for _, path := range paths {
+		i := slices.IndexFunc(projects, func(p Project) bool { return p.Path == path })
+		if i < 0 {
+			return nil, fmt.Errorf("%s: not found in %s", path, ProjectsFileName)
+		}
+		selected = append(selected, projects[i])
+	}
+	return selected, nil
+}
+
 func (w *Workspace) TotalProjectCount() int {
 	count := len(w.Projects)
 	for _, child := range w.Children {
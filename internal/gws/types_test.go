@@ -0,0 +1,21 @@
+package gws
+
+import "testing"
+
+func TestSelectProjects(t *testing.T) {
+	projects := []Project{{Path: "api"}, {Path: "web"}, {Path: "lib"}}
+
+	all, err := SelectProjects(projects, nil)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected all projects, got %v (%v)", all, err)
+	}
+
+	selected, err := SelectProjects(projects, []string{"lib", "api"})
+	if err != nil || len(selected) != 2 || selected[0].Path != "lib" || selected[1].Path != "api" {
+		t.Errorf("unexpected selection: %v (%v)", selected, err)
+	}
+
+	if _, err := SelectProjects(projects, []string{"nope"}); err == nil {
+		t.Error("expected error for unknown project")
+	}
+}
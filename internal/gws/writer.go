@@ -27,7 +27,7 @@ func AddProject(workspaceRoot string, project Project) error {
 	}
 	defer file.Close()
 
-	line := formatProjectLine(project)
+	line := FormatProjectLine(project)
 	if _, err := file.WriteString(line + "\n"); err != nil {
 		return fmt.Errorf("failed to write to projects file: %w", err)
 	}
@@ -51,6 +51,26 @@ func RemoveProject(workspaceRoot string, projectPath string) error {
 	})
 }
 
+func UpdateProject(workspaceRoot string, project Project) error {
+	filePath := project.Origin.File
+	if filePath == "" {
+		location := getProjectsFileLocation(workspaceRoot)
+		if location == nil {
+			return fmt.Errorf("no projects file found")
+		}
+		filePath = location.Path
+	}
+
+	if prefix := project.Origin.Prefix; prefix != "" {
+		project.Path = strings.TrimPrefix(project.Path, prefix+"/")
+	}
+
+	return replaceLineInFile(filePath, project.Origin.Line, func(line string) bool {
+		parts := strings.Split(line, "|")
+		return strings.TrimSpace(parts[0]) == project.Path
+	}, FormatProjectLine(project))
+}
+
 func AddWorkspace(workspaceRoot string, ws *Workspace) error {
 	location := getWorkspacesFileLocation(workspaceRoot)
 	if location == nil {
@@ -94,23 +114,68 @@ func RemoveWorkspace(workspaceRoot string, workspacePath string) error {
 	})
 }
 
-func formatProjectLine(project Project) string {
+func FormatProjectLine(project Project) string {
 	var remoteParts []string
-	for _, remote := range project.Remotes {
-		if remote.Name == "origin" {
-			remoteParts = append(remoteParts, remote.URL)
+	for i, remote := range project.Remotes {
+		if i == 0 && remote.Name == "origin" {
+			remoteParts = append(remoteParts, remote.Written())
 		} else {
-			remoteParts = append(remoteParts, fmt.Sprintf("%s %s", remote.URL, remote.Name))
+			remoteParts = append(remoteParts, fmt.Sprintf("%s %s", remote.Written(), remote.Name))
 		}
 	}
+	if project.DefaultBranch != "" {
+		remoteParts = append(remoteParts, defaultBranchPrefix+project.DefaultBranch)
+	}
 	return fmt.Sprintf("%s | %s", project.Path, strings.Join(remoteParts, " | "))
 }
 
 func formatWorkspaceLine(ws *Workspace) string {
 	if ws.Remote.Name == "origin" || ws.Remote.Name == "" {
-		return fmt.Sprintf("%s | %s", ws.Path, ws.Remote.URL)
+		return fmt.Sprintf("%s | %s", ws.Path, ws.Remote.Written())
+	}
+	return fmt.Sprintf("%s | %s %s", ws.Path, ws.Remote.Written(), ws.Remote.Name)
+}
+
+func replaceLineInFile(filePath string, lineNum int, match func(line string) bool, replacement string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
 	}
-	return fmt.Sprintf("%s | %s %s", ws.Path, ws.Remote.URL, ws.Remote.Name)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	found := false
+	for i, line := range lines {
+		if lineNum > 0 && i != lineNum-1 {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		cleanLine, comment := trimmed, ""
+		if idx := strings.Index(trimmed, "#"); idx != -1 {
+			cleanLine, comment = strings.TrimSpace(trimmed[:idx]), " "+trimmed[idx:]
+		}
+
+		if match(cleanLine) {
+			lines[i] = replacement + comment
+			found = true
+			break
+		}
+	}
+	if !found && lineNum > 0 {
+		return fmt.Errorf("%s:%d: line changed since the workspace was loaded", filePath, lineNum)
+	}
+	if !found {
+		return fmt.Errorf("no matching line in %s", filePath)
+	}
+
+	if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
 }
 
 func removeLineFromFile(filePath string, shouldRemove func(line string) bool) error {
@@ -0,0 +1,142 @@
+package gws
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUpdateProject(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectsFile := filepath.Join(tempDir, ProjectsFileName)
+	content := "# core services\napi | git@old.example.com:api.git # owned by platform\nweb | git@example.com:web.git\n"
+	if err := os.WriteFile(projectsFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := Project{Path: "api", Remotes: []Remote{
+		{Name: "upstream", URL: "git@example.com:upstream/api.git"},
+		{Name: "origin", URL: "git@new.example.com:api.git"},
+	}}
+	if err := UpdateProject(tempDir, project); err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+
+	data, err := os.ReadFile(projectsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# core services\napi | git@example.com:upstream/api.git upstream | git@new.example.com:api.git origin # owned by platform\nweb | git@example.com:web.git\n"
+	if string(data) != want {
+		t.Errorf("projects file =\n%s\nwant\n%s", data, want)
+	}
+
+	if err := UpdateProject(tempDir, Project{Path: "missing"}); err == nil {
+		t.Error("expected error for unknown project")
+	}
+}
+
+func TestUpdateProjectAtOrigin(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectsFile := filepath.Join(tempDir, ProjectsFileName)
+	content := "api | git@example.com:old/api.git\nweb | git@example.com:web.git\napi | git@example.com:team/api.git\n"
+	if err := os.WriteFile(projectsFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := parseProjectsFile(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := projects[0]
+	project.Remotes = append(project.Remotes, Remote{Name: "upstream", URL: "git@example.com:upstream/api.git"})
+	if err := UpdateProject(tempDir, project); err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+
+	data, err := os.ReadFile(projectsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "api | git@example.com:old/api.git\nweb | git@example.com:web.git\napi | git@example.com:team/api.git | git@example.com:upstream/api.git upstream\n"
+	if string(data) != want {
+		t.Errorf("projects file =\n%s\nwant\n%s", data, want)
+	}
+
+	project.Origin.Line = 2
+	if err := UpdateProject(tempDir, project); err == nil {
+		t.Error("expected error when the origin line no longer defines the project")
+	}
+}
+
+func TestRewriteLineURLs(t *testing.T) {
+	rewrite := func(url string) string {
+		return strings.Replace(url, "https://git.old.com/", "git@git.new.com:", 1)
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"api  |  https://git.old.com/api.git   # core", "api  |  git@git.new.com:api.git   # core"},
+		{"web | https://git.old.com/web.git | https://git.old.com/up/web.git upstream | default=main", "web | git@git.new.com:web.git | git@git.new.com:up/web.git upstream | default=main"},
+		{"# api | https://git.old.com/api.git", "# api | https://git.old.com/api.git"},
+		{"lib | git@github.com:lib.git", "lib | git@github.com:lib.git"},
+	}
+
+	identity := func(url string) string { return url }
+
+	if _, urls, _ := rewriteLineURLs(tests[1].line, rewrite, identity); len(urls) != 2 || urls[1] != "git@git.new.com:up/web.git" {
+		t.Errorf("unexpected rewritten URLs: %v", urls)
+	}
+
+	for _, tt := range tests {
+		if got, _, _ := rewriteLineURLs(tt.line, rewrite, identity); got != tt.want {
+			t.Errorf("rewriteLineURLs(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestRewriteLineURLsResolved(t *testing.T) {
+	resolve := func(url string) string {
+		url = strings.ReplaceAll(url, "${GIT_BASE}", "https://git.old-corp.com")
+		if rest, ok := strings.CutPrefix(url, "corp:"); ok {
+			return "https://git.old-corp.com/" + rest
+		}
+		return url
+	}
+	rewrite := func(pattern, to string) func(string) string {
+		return func(url string) string { return strings.ReplaceAll(url, pattern, to) }
+	}
+
+	tests := []struct {
+		name    string
+		line    string
+		rewrite func(string) string
+		want    string
+		urls    []string
+		kept    []string
+	}{
+		{"variable", "api | ${GIT_BASE}/team/api.git", rewrite("git.old-corp.com", "git.new-corp.com"),
+			"api | ${GIT_BASE}/team/api.git", nil, []string{"${GIT_BASE}/team/api.git"}},
+		{"url rule", "web | corp:team/web.git", rewrite("git.old-corp.com", "git.new-corp.com"),
+			"web | corp:team/web.git", nil, []string{"corp:team/web.git"}},
+		{"literal", "lib | https://git.old-corp.com/lib.git", rewrite("git.old-corp.com", "git.new-corp.com"),
+			"lib | https://git.new-corp.com/lib.git", []string{"https://git.new-corp.com/lib.git"}, nil},
+		{"path after variable", "api | ${GIT_BASE}/team/api.git", rewrite("team/api", "core/api"),
+			"api | ${GIT_BASE}/core/api.git", []string{"https://git.old-corp.com/core/api.git"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, urls, kept := rewriteLineURLs(tt.line, tt.rewrite, resolve)
+			if got != tt.want || !slices.Equal(urls, tt.urls) || !slices.Equal(kept, tt.kept) {
+				t.Errorf("rewriteLineURLs(%q) = %q, %v, %v; want %q, %v, %v", tt.line, got, urls, kept, tt.want, tt.urls, tt.kept)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"gogws/internal/config"
+	"gogws/internal/gws"
+
+	"gopkg.in/yaml.v3"
+)
+
+type WorkDir string
+
+const (
+	WorkDirWorkspace WorkDir = "workspace"
+	WorkDirEachRepo  WorkDir = "each-repo"
+)
+
+const hookWaitDelay = time.Second
+
+type HookSpec struct {
+	Event     HookType          `yaml:"event"`
+	Name      string            `yaml:"name,omitempty"`
+	Command   string            `yaml:"command"`
+	Shell     string            `yaml:"shell,omitempty"`
+	WorkDir   WorkDir           `yaml:"workdir,omitempty"`
+	Env       map[string]string `yaml:"env,omitempty"`
+	Timeout   time.Duration     `yaml:"timeout,omitempty"`
+	Condition string            `yaml:"condition,omitempty"`
+}
+
+type HooksFile struct {
+	Hooks []HookSpec `yaml:"hooks"`
+}
+
+var knownHookTypes = map[HookType]bool{
+	HookPreInit: true, HookPostInit: true,
+	HookPreUpdate: true, HookPostUpdate: true,
+	HookPreClone: true, HookPostClone: true,
+	HookPreFetch: true, HookPostFetch: true,
+	HookPreFF: true, HookPostFF: true,
+	HookPreCheck: true, HookPostCheck: true,
+}
+
+func LoadHooksFile(path string) ([]HookSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file HooksFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for i := range file.Hooks {
+		spec := &file.Hooks[i]
+		if spec.WorkDir == "" {
+			spec.WorkDir = WorkDirWorkspace
+		}
+		if err := spec.validate(); err != nil {
+			return nil, fmt.Errorf("%s: hook #%d: %w", path, i+1, err)
+		}
+	}
+
+	return file.Hooks, nil
+}
+
+func (s *HookSpec) validate() error {
+	if !knownHookTypes[s.Event] {
+		return fmt.Errorf("unknown event %q", s.Event)
+	}
+	if s.Command == "" {
+		return fmt.Errorf("command is required")
+	}
+	if s.WorkDir != WorkDirWorkspace && s.WorkDir != WorkDirEachRepo {
+		return fmt.Errorf("invalid workdir %q (expected %s or %s)", s.WorkDir, WorkDirWorkspace, WorkDirEachRepo)
+	}
+	if s.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	return nil
+}
+
+func (s *HookSpec) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Command
+}
+
+func localHooksFilePath(workspaceRoot string) string {
+	return filepath.Join(workspaceRoot, gws.ConfigDirName, gws.HooksFileName)
+}
+
+func globalHooksFilePath() (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, gws.HooksFileName), nil
+}
+
+func findDeclaredHooks(hookName HookType, path string, origin HookOrigin) ([]*HookInfo, error) {
+	specs, err := LoadHooksFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*HookInfo
+	for i := range specs {
+		if specs[i].Event != hookName {
+			continue
+		}
+		found = append(found, &HookInfo{
+			Name:   hookName,
+			Path:   path,
+			Origin: origin,
+			Spec:   &specs[i],
+		})
+	}
+	return found, nil
+}
+
+func executeDeclaredHook(hook *HookInfo, workspaceRoot string, ctx Context, captureStdout bool) (string, error) {
+	spec := hook.Spec
+
+	if spec.WorkDir == WorkDirWorkspace {
+		return runDeclaredCommand(hook, workspaceRoot, "", ctx, captureStdout)
+	}
+
+	var merged *Response
+	for _, project := range ctx.Projects {
+		dir := filepath.Join(workspaceRoot, project)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		output, err := runDeclaredCommand(hook, dir, project, ctx, captureStdout)
+		if captureStdout {
+			if resp, ok := ParseResponse(output); ok {
+				if merged == nil {
+					merged = &Response{}
+				}
+				merged.Skip = append(merged.Skip, resp.Skip...)
+			} else if output != "" {
+				fmt.Fprint(out, output)
+			}
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", project, err)
+		}
+	}
+
+	if merged == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func runDeclaredCommand(hook *HookInfo, dir, project string, ctx Context, captureStdout bool) (string, error) {
+	spec := hook.Spec
+
+	runCtx := context.Background()
+	if spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(runCtx, spec.Timeout)
+		defer cancel()
+	}
+
+	env := hookEnv(hook, ctx)
+	if project != "" {
+		env = append(env, fmt.Sprintf("GOGWS_PROJECT=%s", project))
+	}
+	for key, value := range spec.Env {
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	if spec.Condition != "" {
+		cond := shellCommand(runCtx, spec.Shell, spec.Condition)
+		cond.Dir = dir
+		cond.Env = env
+		if err := cond.Run(); err != nil {
+			slog.Debug("Hook condition not met", "hook", hook.Label(), "dir", dir, "err", err)
+			return "", nil
+		}
+	}
+
+	cmd := shellCommand(runCtx, spec.Shell, spec.Command)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	output, err := runHookCommand(cmd, captureStdout)
+	if runCtx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("hook timed out after %v", spec.Timeout)
+	}
+	return output, err
+}
+
+func shellCommand(ctx context.Context, shell, command string) *exec.Cmd {
+	if shell == "" {
+		if runtime.GOOS == "windows" {
+			shell = "cmd"
+		} else {
+			shell = "sh"
+		}
+	}
+
+	var cmd *exec.Cmd
+	if shell == "cmd" {
+		cmd = exec.CommandContext(ctx, shell, "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, shell, "-c", command)
+	}
+	killProcessGroup(cmd)
+	cmd.WaitDelay = hookWaitDelay
+	return cmd
+}
@@ -0,0 +1,105 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadHooksFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.yaml")
+	content := `hooks:
+  - event: post-clone
+    name: install
+    command: npm ci
+    workdir: each-repo
+    timeout: 2m
+    condition: test -f package.json
+    env:
+      CI: "1"
+  - event: pre-fetch
+    command: ./check-vpn.sh
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	specs, err := LoadHooksFile(path)
+	if err != nil {
+		t.Fatalf("LoadHooksFile failed: %v", err)
+	}
+
+	if len(specs) != 2 {
+		t.Fatalf("Expected 2 hooks, got %d", len(specs))
+	}
+
+	first := specs[0]
+	if first.Event != HookPostClone || first.WorkDir != WorkDirEachRepo {
+		t.Errorf("Unexpected first hook: %+v", first)
+	}
+	if first.Timeout != 2*time.Minute {
+		t.Errorf("Expected timeout 2m, got %v", first.Timeout)
+	}
+	if first.Env["CI"] != "1" {
+		t.Errorf("Expected env CI=1, got %v", first.Env)
+	}
+
+	if specs[1].WorkDir != WorkDirWorkspace {
+		t.Errorf("Expected default workdir %q, got %q", WorkDirWorkspace, specs[1].WorkDir)
+	}
+}
+
+func TestLoadHooksFile_Missing(t *testing.T) {
+	specs, err := LoadHooksFile(filepath.Join(t.TempDir(), "hooks.yaml"))
+	if err != nil {
+		t.Fatalf("Missing file should not be an error: %v", err)
+	}
+	if len(specs) != 0 {
+		t.Errorf("Expected no hooks, got %d", len(specs))
+	}
+}
+
+func TestLoadHooksFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown event", "hooks:\n  - event: pre-push\n    command: x\n", "unknown event"},
+		{"missing command", "hooks:\n  - event: pre-ff\n", "command is required"},
+		{"invalid workdir", "hooks:\n  - event: pre-ff\n    command: x\n    workdir: home\n", "invalid workdir"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hooks.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadHooksFile(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunDeclaredCommand_TimeoutWithChildHoldingOutput(t *testing.T) {
+	hook := &HookInfo{Name: HookPostClone, Spec: &HookSpec{
+		Event:   HookPostClone,
+		Command: "sleep 10 & echo started; wait",
+		Timeout: 200 * time.Millisecond,
+	}}
+
+	start := time.Now()
+	_, err := runDeclaredCommand(hook, t.TempDir(), "", Context{}, true)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("hook ran for %v despite a %v timeout", elapsed, hook.Spec.Timeout)
+	}
+}
@@ -1,10 +1,15 @@
 package hooks
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gogws/internal/config"
 	"gogws/internal/gws"
@@ -38,13 +43,21 @@ type HookInfo struct {
 	Name   HookType
 	Path   string
 	Origin HookOrigin
+	Spec   *HookSpec
+}
+
+func (h *HookInfo) Label() string {
+	if h.Spec != nil {
+		return fmt.Sprintf("%s (%s)", h.Name, h.Spec.DisplayName())
+	}
+	return string(h.Name)
 }
 
 type Context struct {
-	Command       string
-	WorkspaceRoot string
-	Projects      []string
-	Data          map[string]interface{}
+	Command       string                 `json:"command"`
+	WorkspaceRoot string                 `json:"workspace"`
+	Projects      []string               `json:"projects,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
 }
 
 var globalTrustMode TrustMode = TrustModeAsk
@@ -57,7 +70,24 @@ func GetTrustMode() TrustMode {
 	return globalTrustMode
 }
 
-func findHook(hookName HookType, workspaceRoot string) *HookInfo {
+var out io.Writer = os.Stdout
+
+func SetOutput(w io.Writer) {
+	out = w
+}
+
+type Observer struct {
+	OnStart  func(hook *HookInfo, ctx Context)
+	OnFinish func(hook *HookInfo, ctx Context, duration time.Duration, err error)
+}
+
+var observer Observer
+
+func SetObserver(o Observer) {
+	observer = o
+}
+
+func findFileHook(hookName HookType, workspaceRoot string) *HookInfo {
 	localHooksDir := filepath.Join(workspaceRoot, gws.ConfigDirName, gws.HooksDirName)
 	localHookPath := filepath.Join(localHooksDir, string(hookName))
 
@@ -84,61 +114,168 @@ func findHook(hookName HookType, workspaceRoot string) *HookInfo {
 	return nil
 }
 
-func executeHook(hook *HookInfo, workspaceRoot string, ctx Context) error {
-	if hook == nil {
-		return nil
-	}
-
-	if hook.Origin == OriginLocal {
-		if !IsWorkspaceTrusted(workspaceRoot) {
-			switch globalTrustMode {
-			case TrustModeSkip:
-				fmt.Printf("[hook:%s] Skipping untrusted hook: %s\n", hook.Origin, hook.Name)
-				return nil
-			case TrustModeAll:
-				fmt.Printf("[hook:%s] Running hook (trust-mode=all): %s\n", hook.Origin, hook.Name)
-			case TrustModeAsk:
-				result := PromptTrust(string(hook.Name), hook.Path, workspaceRoot)
-				switch result {
-				case TrustResultSkip:
-					fmt.Printf("[hook:%s] Skipped by user: %s\n", hook.Origin, hook.Name)
-					return nil
-				case TrustResultRunAndTrust:
-					if err := AddToTrusted(workspaceRoot); err != nil {
-						fmt.Printf("Warning: failed to add workspace to trusted list: %v\n", err)
-					} else {
-						fmt.Printf("Workspace added to trusted list\n")
-					}
-				}
+func findHooks(hookName HookType, workspaceRoot string) ([]*HookInfo, error) {
+	var found []*HookInfo
+
+	if hook := findFileHook(hookName, workspaceRoot); hook != nil {
+		found = append(found, hook)
+	}
+
+	if globalPath, err := globalHooksFilePath(); err == nil {
+		declared, err := findDeclaredHooks(hookName, globalPath, OriginGlobal)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, declared...)
+	}
+
+	declared, err := findDeclaredHooks(hookName, localHooksFilePath(workspaceRoot), OriginLocal)
+	if err != nil {
+		return nil, err
+	}
+	found = append(found, declared...)
+
+	return found, nil
+}
+
+func checkTrust(hook *HookInfo, workspaceRoot string) bool {
+	if hook.Origin != OriginLocal {
+		fmt.Fprintf(out, "[hook:%s] %s\n", hook.Origin, hook.Label())
+		return true
+	}
+
+	if IsWorkspaceTrusted(workspaceRoot) {
+		fmt.Fprintf(out, "[hook:%s:trusted] %s\n", hook.Origin, hook.Label())
+		return true
+	}
+
+	switch globalTrustMode {
+	case TrustModeSkip:
+		fmt.Fprintf(out, "[hook:%s] Skipping untrusted hook: %s\n", hook.Origin, hook.Label())
+		return false
+	case TrustModeAll:
+		fmt.Fprintf(out, "[hook:%s] Running hook (trust-mode=all): %s\n", hook.Origin, hook.Label())
+	case TrustModeAsk:
+		location := hook.Path
+		if hook.Spec != nil {
+			location = fmt.Sprintf("%s (command: %s)", hook.Path, hook.Spec.Command)
+		}
+		result := PromptTrust(string(hook.Name), location, workspaceRoot)
+		switch result {
+		case TrustResultSkip:
+			fmt.Fprintf(out, "[hook:%s] Skipped by user: %s\n", hook.Origin, hook.Label())
+			return false
+		case TrustResultRunAndTrust:
+			if err := AddToTrusted(workspaceRoot); err != nil {
+				fmt.Fprintf(out, "Warning: failed to add workspace to trusted list: %v\n", err)
+			} else {
+				fmt.Fprintf(out, "Workspace added to trusted list\n")
 			}
-		} else {
-			fmt.Printf("[hook:%s:trusted] %s\n", hook.Origin, hook.Name)
 		}
-	} else {
-		fmt.Printf("[hook:%s] %s\n", hook.Origin, hook.Name)
 	}
 
-	cmd := exec.Command(hook.Path)
-	cmd.Dir = workspaceRoot
-	cmd.Env = append(os.Environ(),
+	return true
+}
+
+func hookEnv(hook *HookInfo, ctx Context) []string {
+	env := append(os.Environ(),
 		fmt.Sprintf("GOGWS_COMMAND=%s", ctx.Command),
 		fmt.Sprintf("GOGWS_WORKSPACE=%s", ctx.WorkspaceRoot),
 		fmt.Sprintf("GOGWS_HOOK_NAME=%s", hook.Name),
 		fmt.Sprintf("GOGWS_HOOK_ORIGIN=%s", hook.Origin),
+		fmt.Sprintf("GOGWS_PROJECTS=%s", strings.Join(ctx.Projects, "\n")),
 	)
+	if data, err := json.Marshal(ctx); err == nil {
+		env = append(env, fmt.Sprintf("GOGWS_CONTEXT=%s", data))
+	}
+	return env
+}
+
+func executeHook(hook *HookInfo, workspaceRoot string, ctx Context, captureStdout bool) (string, error) {
+	if hook == nil {
+		return "", nil
+	}
 
-	cmd.Stdout = os.Stdout
+	if !checkTrust(hook, workspaceRoot) {
+		return "", nil
+	}
+
+	if observer.OnStart != nil {
+		observer.OnStart(hook, ctx)
+	}
+	start := time.Now()
+
+	output, err := runHook(hook, workspaceRoot, ctx, captureStdout)
+
+	if observer.OnFinish != nil {
+		observer.OnFinish(hook, ctx, time.Since(start), err)
+	}
+	return output, err
+}
+
+func runHook(hook *HookInfo, workspaceRoot string, ctx Context, captureStdout bool) (string, error) {
+	if hook.Spec != nil {
+		return executeDeclaredHook(hook, workspaceRoot, ctx, captureStdout)
+	}
+
+	cmd := exec.Command(hook.Path)
+	cmd.Dir = workspaceRoot
+	cmd.Env = hookEnv(hook, ctx)
+
+	return runHookCommand(cmd, captureStdout)
+}
+
+func runHookCommand(cmd *exec.Cmd, captureStdout bool) (string, error) {
 	cmd.Stderr = os.Stderr
+	if !captureStdout {
+		cmd.Stdout = out
+		return "", cmd.Run()
+	}
 
-	return cmd.Run()
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	return stdout.String(), err
 }
 
 func Run(hookName HookType, workspaceRoot string, ctx Context) error {
-	hook := findHook(hookName, workspaceRoot)
-	if hook == nil {
-		return nil
+	found, err := findHooks(hookName, workspaceRoot)
+	if err != nil {
+		return err
 	}
-	return executeHook(hook, workspaceRoot, ctx)
+
+	for _, hook := range found {
+		if _, err := executeHook(hook, workspaceRoot, ctx, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RunPre(hookName HookType, workspaceRoot string, ctx Context) (*Response, error) {
+	found, err := findHooks(hookName, workspaceRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged *Response
+	for _, hook := range found {
+		output, err := executeHook(hook, workspaceRoot, ctx, true)
+		resp, ok := ParseResponse(output)
+		if !ok && output != "" {
+			fmt.Fprint(out, output)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if merged == nil {
+				merged = &Response{}
+			}
+			merged.Skip = append(merged.Skip, resp.Skip...)
+		}
+	}
+	return merged, nil
 }
 
 func PreInit(workspaceRoot string) error {
@@ -156,10 +293,11 @@ func PostInit(workspaceRoot string, projects []string) error {
 	})
 }
 
-func PreUpdate(workspaceRoot string) error {
-	return Run(HookPreUpdate, workspaceRoot, Context{
+func PreUpdate(workspaceRoot string, projects []string) (*Response, error) {
+	return RunPre(HookPreUpdate, workspaceRoot, Context{
 		Command:       "update",
 		WorkspaceRoot: workspaceRoot,
+		Projects:      projects,
 	})
 }
 
@@ -171,11 +309,14 @@ func PostUpdate(workspaceRoot string, cloned []string) error {
 	})
 }
 
-func PreClone(workspaceRoot string, repoPath string) error {
-	return Run(HookPreClone, workspaceRoot, Context{
+func PreClone(workspaceRoot string, repoPath string, urls []string) (*Response, error) {
+	return RunPre(HookPreClone, workspaceRoot, Context{
 		Command:       "clone",
 		WorkspaceRoot: workspaceRoot,
 		Projects:      []string{repoPath},
+		Data: map[string]interface{}{
+			"urls": urls,
+		},
 	})
 }
 
@@ -190,36 +331,41 @@ func PostClone(workspaceRoot string, repoPath string, success bool) error {
 	})
 }
 
-func PreFetch(workspaceRoot string) error {
-	return Run(HookPreFetch, workspaceRoot, Context{
+func PreFetch(workspaceRoot string, projects []string) (*Response, error) {
+	return RunPre(HookPreFetch, workspaceRoot, Context{
 		Command:       "fetch",
 		WorkspaceRoot: workspaceRoot,
+		Projects:      projects,
 	})
 }
 
-func PostFetch(workspaceRoot string, fetched int) error {
+func PostFetch(workspaceRoot string, fetched []string, incoming any) error {
 	return Run(HookPostFetch, workspaceRoot, Context{
 		Command:       "fetch",
 		WorkspaceRoot: workspaceRoot,
+		Projects:      fetched,
 		Data: map[string]interface{}{
-			"fetched": fetched,
+			"fetched":  len(fetched),
+			"incoming": incoming,
 		},
 	})
 }
 
-func PreFF(workspaceRoot string) error {
-	return Run(HookPreFF, workspaceRoot, Context{
+func PreFF(workspaceRoot string, projects []string) (*Response, error) {
+	return RunPre(HookPreFF, workspaceRoot, Context{
 		Command:       "ff",
 		WorkspaceRoot: workspaceRoot,
+		Projects:      projects,
 	})
 }
 
-func PostFF(workspaceRoot string, pulled int) error {
+func PostFF(workspaceRoot string, pulled []string) error {
 	return Run(HookPostFF, workspaceRoot, Context{
 		Command:       "ff",
 		WorkspaceRoot: workspaceRoot,
+		Projects:      pulled,
 		Data: map[string]interface{}{
-			"pulled": pulled,
+			"pulled": len(pulled),
 		},
 	})
 }
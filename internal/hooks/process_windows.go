@@ -0,0 +1,5 @@
+package hooks
+
+import "os/exec"
+
+func killProcessGroup(cmd *exec.Cmd) {}
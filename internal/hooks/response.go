@@ -0,0 +1,78 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gogws/internal/engine"
+)
+
+type SkipEntry struct {
+	Project string `json:"project"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type Response struct {
+	Skip []SkipEntry `json:"skip"`
+}
+
+func ParseResponse(output string) (*Response, bool) {
+	trimmed := strings.TrimSpace(output)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil, false
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &raw); err != nil {
+		return nil, false
+	}
+	if _, ok := raw["skip"]; !ok {
+		return nil, false
+	}
+
+	var resp Response
+	if err := json.Unmarshal([]byte(trimmed), &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+func (r *Response) SkipReason(project string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	for _, entry := range r.Skip {
+		if entry.Project == project {
+			return entry.Reason, true
+		}
+	}
+	return "", false
+}
+
+func (r *Response) Filter(hookName HookType, commands []engine.RepoCommand) ([]engine.RepoCommand, []engine.Result) {
+	if r == nil || len(r.Skip) == 0 {
+		return commands, nil
+	}
+
+	kept := make([]engine.RepoCommand, 0, len(commands))
+	var skipped []engine.Result
+
+	for _, cmd := range commands {
+		reason, ok := r.SkipReason(cmd.RepoName)
+		if !ok {
+			kept = append(kept, cmd)
+			continue
+		}
+		skipped = append(skipped, engine.Skip(cmd, SkipMessage(hookName, reason)))
+	}
+
+	return kept, skipped
+}
+
+func SkipMessage(hookName HookType, reason string) string {
+	if reason == "" {
+		return fmt.Sprintf("vetoed by %s hook", hookName)
+	}
+	return fmt.Sprintf("%s hook: %s", hookName, reason)
+}
@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"testing"
+
+	"gogws/internal/engine"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		wantOK bool
+		want   int
+	}{
+		{"skip list", `{"skip":[{"project":"api","reason":"wip"}]}`, true, 1},
+		{"empty skip list", `{"skip":[]}`, true, 0},
+		{"surrounding whitespace", "\n  {\"skip\":[{\"project\":\"api\"}]}\n", true, 1},
+		{"plain text", "checking branches...\n", false, 0},
+		{"json without skip", `{"status":"ok"}`, false, 0},
+		{"invalid json", `{"skip":[`, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, ok := ParseResponse(tt.output)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseResponse() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && len(resp.Skip) != tt.want {
+				t.Errorf("Expected %d skip entries, got %d", tt.want, len(resp.Skip))
+			}
+		})
+	}
+}
+
+func TestResponseFilter(t *testing.T) {
+	resp := &Response{Skip: []SkipEntry{
+		{Project: "api", Reason: "on wip branch"},
+		{Project: "web"},
+	}}
+
+	commands := []engine.RepoCommand{
+		engine.NewGitCommand("/ws/api", "api", "pull"),
+		engine.NewGitCommand("/ws/web", "web", "pull"),
+		engine.NewGitCommand("/ws/lib", "lib", "pull"),
+	}
+
+	kept, skipped := resp.Filter(HookPreFF, commands)
+
+	if len(kept) != 1 || kept[0].RepoName != "lib" {
+		t.Fatalf("Expected only lib to be kept, got %v", kept)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("Expected 2 skipped results, got %d", len(skipped))
+	}
+	if skipped[0].SkipReason != "pre-ff hook: on wip branch" {
+		t.Errorf("Unexpected skip reason: %q", skipped[0].SkipReason)
+	}
+	if skipped[1].SkipReason != "vetoed by pre-ff hook" {
+		t.Errorf("Unexpected skip reason: %q", skipped[1].SkipReason)
+	}
+}
+
+func TestResponseFilter_NilResponse(t *testing.T) {
+	var resp *Response
+	commands := []engine.RepoCommand{engine.NewGitCommand("/ws/api", "api", "pull")}
+
+	kept, skipped := resp.Filter(HookPreFF, commands)
+	if len(kept) != 1 || len(skipped) != 0 {
+		t.Errorf("Nil response should keep all commands")
+	}
+}
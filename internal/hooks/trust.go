@@ -71,15 +71,15 @@ func matchPattern(pattern, path string) bool {
 }
 
 func PromptTrust(hookName, hookPath, workspacePath string) TrustResult {
-	fmt.Printf("\n[hook:local] Hook '%s' found at: %s\n", hookName, hookPath)
-	fmt.Printf("Workspace: %s\n", workspacePath)
-	fmt.Println("This workspace is not in your trusted list.")
-	fmt.Println()
-	fmt.Println("Options:")
-	fmt.Println("  [r] Run this hook")
-	fmt.Println("  [s] Skip this hook")
-	fmt.Println("  [t] Run and add workspace to trusted list")
-	fmt.Print("Choose [r/s/t]: ")
+	fmt.Fprintf(out, "\n[hook:local] Hook '%s' found at: %s\n", hookName, hookPath)
+	fmt.Fprintf(out, "Workspace: %s\n", workspacePath)
+	fmt.Fprintln(out, "This workspace is not in your trusted list.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Options:")
+	fmt.Fprintln(out, "  [r] Run this hook")
+	fmt.Fprintln(out, "  [s] Skip this hook")
+	fmt.Fprintln(out, "  [t] Run and add workspace to trusted list")
+	fmt.Fprint(out, "Choose [r/s/t]: ")
 
 	reader := bufio.NewReader(os.Stdin)
 	input, err := reader.ReadString('\n')
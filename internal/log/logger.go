@@ -12,6 +12,7 @@ import (
 var (
 	baseHandler *log.Logger
 	logger      *slog.Logger
+	verbose     bool
 )
 
 func init() {
@@ -40,9 +41,14 @@ func init() {
 }
 
 func SetVerbose(v bool) {
+	verbose = v
 	if v {
 		baseHandler.SetLevel(log.DebugLevel)
 	} else {
 		baseHandler.SetLevel(log.InfoLevel)
 	}
 }
+
+func IsVerbose() bool {
+	return verbose
+}
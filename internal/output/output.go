@@ -0,0 +1,170 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/ui/cli"
+	"gogws/internal/ui/progress"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+var stdout io.Writer = os.Stdout
+
+func SetStdout(w io.Writer) {
+	stdout = w
+}
+
+func Stdout() io.Writer {
+	return stdout
+}
+
+func IsTerminal() bool {
+	f, ok := stdout.(*os.File)
+	return ok && term.IsTerminal(f.Fd())
+}
+
+type Writer struct {
+	Format    string
+	formatter export.Formatter
+	renderer  *cli.Renderer
+	summary   *engine.OutputHandler
+}
+
+func New(format string) (*Writer, error) {
+	var formatter export.Formatter
+	if !export.IsText(format) {
+		var err error
+		if formatter, err = export.Lookup(format); err != nil {
+			return nil, exitcode.New(exitcode.Usage, err)
+		}
+	}
+
+	renderer := cli.NewRenderer()
+	summary := engine.NewOutputHandler(renderer, false)
+	summary.Out = stdout
+	return &Writer{
+		Format:    format,
+		formatter: formatter,
+		renderer:  renderer,
+		summary:   summary,
+	}, nil
+}
+
+func NewForData(format, command string) (*Writer, error) {
+	w, err := New(format)
+	if err != nil {
+		return nil, err
+	}
+	if !w.IsText() && w.formatter.Data == nil {
+		return nil, exitcode.New(exitcode.Usage, fmt.Errorf("format %s is not supported for %s (supported: text, json, yaml, %s<go-template>)", w.formatter.Name, command, export.TemplatePrefix))
+	}
+	return w, nil
+}
+
+func (w *Writer) IsText() bool {
+	return export.IsText(w.Format)
+}
+
+func (w *Writer) Renderer() *cli.Renderer {
+	return w.renderer
+}
+
+func (w *Writer) Success(message string) {
+	w.message(w.renderer.RenderSuccess, message)
+}
+
+func (w *Writer) Info(message string) {
+	w.message(w.renderer.RenderInfo, message)
+}
+
+func (w *Writer) Warning(message string) {
+	w.message(w.renderer.RenderWarning, message)
+}
+
+func (w *Writer) Error(message string) {
+	w.message(w.renderer.RenderError, message)
+}
+
+func (w *Writer) message(render func(string) string, message string) {
+	if w.IsText() {
+		fmt.Fprintln(stdout, render(message))
+		return
+	}
+	fmt.Fprintln(os.Stderr, message)
+}
+
+func (w *Writer) Text(text string) {
+	if w.IsText() {
+		fmt.Fprintln(stdout, text)
+	}
+}
+
+func (w *Writer) Prompt(text string) {
+	if w.IsText() {
+		fmt.Fprint(stdout, text)
+		return
+	}
+	fmt.Fprint(os.Stderr, text)
+}
+
+func (w *Writer) Progress() (stop func()) {
+	if !w.IsText() {
+		return func() {}
+	}
+
+	interactive := IsTerminal() && lipgloss.ColorProfile() != termenv.Ascii
+	width := 0
+	if f, ok := stdout.(*os.File); ok {
+		width, _, _ = term.GetSize(f.Fd())
+	}
+
+	live := progress.NewLive(stdout, w.renderer, interactive, width)
+	return engine.AddObserver(live.Observer())
+}
+
+func (w *Writer) Results(result *engine.ExecuteResult, command, verb string) error {
+	return w.ResultsOutput(result, export.NewResultsOutput(result, command), verb)
+}
+
+func (w *Writer) ResultsOutput(result *engine.ExecuteResult, output export.ResultsOutput, verb string) error {
+	if w.IsText() {
+		w.summary.RenderSummary(result, verb)
+		return nil
+	}
+
+	formatted, err := export.FormatResults(output, w.Format)
+	if err != nil {
+		return fmt.Errorf("failed to export %s results: %w", output.Command, err)
+	}
+	fmt.Fprintln(stdout, formatted)
+	return nil
+}
+
+func (w *Writer) Status(statuses []git.RepositoryStatus) error {
+	formatted, err := export.Format(statuses, w.Format)
+	if err != nil {
+		return fmt.Errorf("failed to export status: %w", err)
+	}
+	if formatted != "" {
+		fmt.Fprintln(stdout, formatted)
+	}
+	return nil
+}
+
+func (w *Writer) Data(command string, v any) error {
+	formatted, err := export.FormatData(v, command, w.Format)
+	if err != nil {
+		return fmt.Errorf("failed to export %s output: %w", command, err)
+	}
+	fmt.Fprintln(stdout, formatted)
+	return nil
+}
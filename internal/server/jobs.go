@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"gogws/internal/actions"
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+	"gogws/internal/hooks"
+)
+
+const (
+	maxQueuedJobs = 32
+	maxKeptJobs   = 100
+)
+
+var jobCommands = []string{"fetch", "ff", "update"}
+
+var errQueueFull = errors.New("job queue is full")
+
+func isJobCommand(command string) bool {
+	return slices.Contains(jobCommands, command)
+}
+
+type jobList struct {
+	Jobs []export.JobOutput `json:"jobs"`
+}
+
+type jobQueue struct {
+	mu      sync.Mutex
+	nextID  int
+	jobs    []*export.JobOutput
+	pending chan *export.JobOutput
+	execute func(command string) (*engine.ExecuteResult, []git.RepoRefChanges, error)
+	broker  *broker
+	now     func() time.Time
+}
+
+func newJobQueue(execute func(command string) (*engine.ExecuteResult, []git.RepoRefChanges, error), b *broker) *jobQueue {
+	return &jobQueue{
+		pending: make(chan *export.JobOutput, maxQueuedJobs),
+		execute: execute,
+		broker:  b,
+		now:     time.Now,
+	}
+}
+
+func (q *jobQueue) enqueue(command string) (export.JobOutput, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.nextID++
+	job := export.NewJobOutput(strconv.Itoa(q.nextID), command, q.now())
+
+	select {
+	case q.pending <- &job:
+	default:
+		return job, errQueueFull
+	}
+
+	q.jobs = append(q.jobs, &job)
+	if len(q.jobs) > maxKeptJobs {
+		q.jobs = q.jobs[len(q.jobs)-maxKeptJobs:]
+	}
+	q.publish(&job)
+
+	return job, nil
+}
+
+func (q *jobQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-q.pending:
+			q.update(job, func(j *export.JobOutput) {
+				now := q.now()
+				j.State = export.JobRunning
+				j.StartedAt = &now
+			})
+
+			result, incoming, err := q.execute(job.Command)
+			code := exitcode.Code(err)
+			if err == nil {
+				code = exitcode.Code(exitcode.FromResult(result, job.Command))
+			}
+
+			q.update(job, func(j *export.JobOutput) {
+				j.Finish(result, incoming, code, err, q.now())
+			})
+		}
+	}
+}
+
+func (q *jobQueue) update(job *export.JobOutput, apply func(j *export.JobOutput)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	apply(job)
+	q.publish(job)
+}
+
+func (q *jobQueue) publish(job *export.JobOutput) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+	q.broker.publish("job", 0, data)
+}
+
+func (q *jobQueue) get(id string) (export.JobOutput, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range q.jobs {
+		if job.ID == id {
+			return *job, true
+		}
+	}
+	return export.JobOutput{}, false
+}
+
+func (q *jobQueue) list() jobList {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	list := jobList{Jobs: make([]export.JobOutput, len(q.jobs))}
+	for i, job := range q.jobs {
+		list.Jobs[i] = *job
+	}
+	return list
+}
+
+func (s *Server) runJob(command string) (*engine.ExecuteResult, []git.RepoRefChanges, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	s.stream.SetCommand(command)
+	defer s.stream.SetCommand("serve")
+
+	root := s.opts.WorkspaceRoot
+	opts := actions.Options{Parallel: s.opts.Parallel, StopOnError: s.opts.StopOnError}
+
+	if command == "update" {
+		result, err := s.update(opts)
+		return result, nil, err
+	}
+
+	ws, err := gws.New(root).Recursive(false).Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	if command == "ff" {
+		result, err := actions.FF(root, ws.Projects, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := hooks.PostFF(root, result.SuccessNames()); err != nil {
+			return result, nil, fmt.Errorf("post-ff hook failed: %w", err)
+		}
+		return result, nil, nil
+	}
+
+	result, changes, err := actions.Fetch(root, ws.Projects, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	incoming, err := actions.RecordIncoming(root, changes)
+	if err != nil {
+		slog.Warn("Failed to save incoming report", "error", err)
+	}
+
+	if err := hooks.PostFetch(root, result.SuccessNames(), export.NewIncomingOutput(incoming.Repositories, incoming.FetchedAt).Repositories); err != nil {
+		return result, changes, fmt.Errorf("post-fetch hook failed: %w", err)
+	}
+
+	return result, changes, nil
+}
+
+func (s *Server) update(opts actions.Options) (*engine.ExecuteResult, error) {
+	root := s.opts.WorkspaceRoot
+
+	ws, err := gws.New(root).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve workspace: %w", err)
+	}
+
+	var pending []string
+	for _, child := range ws.MissingWorkspaces() {
+		pending = append(pending, child.Path)
+	}
+	for _, p := range ws.MissingProjects() {
+		pending = append(pending, p.Path)
+	}
+
+	resp, err := hooks.PreUpdate(root, pending)
+	if err != nil {
+		return nil, fmt.Errorf("pre-update hook failed: %w", err)
+	}
+
+	combined := engine.NewExecuteResult()
+	actions.Merge(combined, actions.CloneWorkspaces(root, ws.MissingWorkspaces(), resp, opts))
+
+	var cloned []string
+	if missing := ws.MissingProjects(); len(missing) > 0 {
+		result := actions.CloneProjects(root, missing, resp, opts)
+		actions.Merge(combined, result)
+		cloned = result.SuccessNames()
+	}
+
+	if err := hooks.PostUpdate(root, cloned); err != nil {
+		return combined, fmt.Errorf("post-update hook failed: %w", err)
+	}
+
+	return combined, nil
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gogws/internal/actions"
+	"gogws/internal/cache"
+	"gogws/internal/events"
+	"gogws/internal/export"
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+const APIPrefix = "/api/v1"
+
+type Options struct {
+	WorkspaceRoot string
+	Token         string
+	Parallel      int
+	StopOnError   bool
+}
+
+type Server struct {
+	opts   Options
+	broker *broker
+	jobs   *jobQueue
+	stream *events.Stream
+	cache  *cache.Cache
+	runMu  sync.Mutex
+}
+
+func New(opts Options) *Server {
+	s := &Server{opts: opts, broker: newBroker(), cache: cache.Load(opts.WorkspaceRoot)}
+	s.stream = events.NewStream(s.broker, "serve")
+	s.jobs = newJobQueue(s.runJob, s.broker)
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPrefix+"/workspace", s.handleWorkspace)
+	mux.HandleFunc("GET "+APIPrefix+"/status", s.handleStatus)
+	mux.HandleFunc("GET "+APIPrefix+"/status/{path...}", s.handleProjectStatus)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs", s.handleJobs)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs/{id}", s.handleJob)
+	mux.HandleFunc("POST "+APIPrefix+"/{command}", s.handleStartJob)
+	mux.HandleFunc("GET "+APIPrefix+"/events", s.broker.serveHTTP)
+
+	return s.authenticate(mux)
+}
+
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	s.stream.Attach()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.jobs.run(ctx)
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+	defer done()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if s.opts.Token == "" {
+		return next
+	}
+
+	expected := []byte(s.opts.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" {
+			token = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleWorkspace(w http.ResponseWriter, r *http.Request) {
+	ws, err := gws.New(s.opts.WorkspaceRoot).Load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to resolve workspace: %w", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, export.NewWorkspaceOutput(ws))
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	ws, err := gws.New(s.opts.WorkspaceRoot).Load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to resolve workspace: %w", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, export.NewStatusOutput(s.statuses(ws.Projects, r.URL.Query().Get("cache") != "false")))
+}
+
+func (s *Server) handleProjectStatus(w http.ResponseWriter, r *http.Request) {
+	ws, err := gws.New(s.opts.WorkspaceRoot).Load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to resolve workspace: %w", err))
+		return
+	}
+
+	path := r.PathValue("path")
+	for _, p := range ws.Projects {
+		if p.Path == path {
+			statuses := s.statuses([]gws.Project{p}, r.URL.Query().Get("cache") != "false")
+			writeJSON(w, http.StatusOK, export.ToRepositoryOutput(statuses[0]))
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Errorf("unknown project: %s", path))
+}
+
+func (s *Server) statuses(projects []gws.Project, useCache bool) []git.RepositoryStatus {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	s.stream.SetCommand("status")
+	defer s.stream.SetCommand("serve")
+
+	if !useCache {
+		return actions.Status(s.opts.WorkspaceRoot, projects, s.opts.Parallel, nil)
+	}
+
+	statuses := actions.Status(s.opts.WorkspaceRoot, projects, s.opts.Parallel, s.cache)
+	if err := s.cache.Save(); err != nil {
+		slog.Warn("Failed to save status cache", "err", err)
+	}
+	return statuses
+}
+
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.jobs.list())
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.jobs.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown job: %s", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleStartJob(w http.ResponseWriter, r *http.Request) {
+	command := r.PathValue("command")
+	if !isJobCommand(command) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown action: %s (available: %s)", command, strings.Join(jobCommands, ", ")))
+		return
+	}
+
+	job, err := s.jobs.enqueue(command)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+
+	w.Header().Set("Location", APIPrefix+"/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		slog.Debug("Failed to write response", "err", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/exitcode"
+	"gogws/internal/export"
+	"gogws/internal/git"
+)
+
+func TestAuthentication(t *testing.T) {
+	handler := New(Options{WorkspaceRoot: t.TempDir(), Token: "secret"}).Handler()
+
+	tests := []struct {
+		name   string
+		header string
+		query  string
+		want   int
+	}{
+		{"missing", "", "", http.StatusUnauthorized},
+		{"wrong", "Bearer nope", "", http.StatusUnauthorized},
+		{"header", "Bearer secret", "", http.StatusOK},
+		{"query", "", "?token=secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, APIPrefix+"/jobs"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("got %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobQueue(t *testing.T) {
+	ran := make(chan string, 1)
+	q := newJobQueue(func(command string) (*engine.ExecuteResult, []git.RepoRefChanges, error) {
+		ran <- command
+		result := engine.NewExecuteResult()
+		result.AddResult(engine.Result{Command: engine.NewGitCommand("/tmp/api", "api", "fetch"), Error: context.Canceled})
+		return result, nil, nil
+	}, newBroker())
+
+	job, err := q.enqueue("fetch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.ID != "1" || job.State != export.JobQueued {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.run(ctx)
+
+	if command := <-ran; command != "fetch" {
+		t.Fatalf("ran %s, want fetch", command)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got, ok := q.get("1")
+		if ok && got.State == export.JobFinished {
+			if got.ExitCode == nil || *got.ExitCode != exitcode.PartialFailure {
+				t.Errorf("expected partial failure exit code, got %v", got.ExitCode)
+			}
+			if got.Result == nil || got.Result.Schema != "gogws/fetch/v1" || got.Result.Failed != 1 {
+				data, _ := json.Marshal(got.Result)
+				t.Errorf("unexpected result: %s", data)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not finish: %+v", got)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, ok := q.get("2"); ok {
+		t.Error("unknown job should not be found")
+	}
+}
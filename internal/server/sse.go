@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	clientBuffer      = 256
+	keepAliveInterval = 15 * time.Second
+)
+
+type broker struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{clients: make(map[chan []byte]struct{})}
+}
+
+func (b *broker) Write(p []byte) (int, error) {
+	var head struct {
+		Type string `json:"type"`
+		Seq  int    `json:"seq"`
+	}
+	if err := json.Unmarshal(p, &head); err != nil {
+		return len(p), nil
+	}
+
+	b.publish(head.Type, head.Seq, bytes.TrimSpace(p))
+	return len(p), nil
+}
+
+func (b *broker) publish(event string, id int, data []byte) {
+	var msg []byte
+	if id > 0 {
+		msg = fmt.Appendf(msg, "id: %d\n", id)
+	}
+	msg = fmt.Appendf(msg, "event: %s\ndata: %s\n\n", event, data)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.clients {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+func (b *broker) subscribe() chan []byte {
+	ch := make(chan []byte, clientBuffer)
+	b.mu.Lock()
+	b.clients[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan []byte) {
+	b.mu.Lock()
+	delete(b.clients, ch)
+	b.mu.Unlock()
+}
+
+func (b *broker) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	ch := b.subscribe()
+	defer b.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-ch:
+			if _, err := w.Write(msg); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -39,19 +39,18 @@ func LoadThemeFromFile(path string) (Theme, error) {
 		return DefaultTheme, fmt.Errorf("failed to parse theme file: %w", err)
 	}
 
-	theme := Theme{
-		Title:   createStyle(config.Colors.Title, config.Styles.TitleBold),
-		Success: createStyle(config.Colors.Success, false),
-		Warning: createStyle(config.Colors.Warning, false),
-		Error:   createStyle(config.Colors.Error, false),
-		Info:    createStyle(config.Colors.Info, false),
-		Subtle:  createStyle(config.Colors.Subtle, false),
-		Path:    createStyle(config.Colors.Path, config.Styles.PathBold),
-		Branch:  createStyle(config.Colors.Branch, false),
-		Remote:  createStyle(config.Colors.Remote, false),
-		Status:  createStyle(config.Colors.Status, false),
-		Stats:   createStyle(config.Colors.Stats, false),
-	}
+	theme := DefaultTheme
+	theme.Title = createStyle(config.Colors.Title, config.Styles.TitleBold)
+	theme.Success = createStyle(config.Colors.Success, false)
+	theme.Warning = createStyle(config.Colors.Warning, false)
+	theme.Error = createStyle(config.Colors.Error, false)
+	theme.Info = createStyle(config.Colors.Info, false)
+	theme.Subtle = createStyle(config.Colors.Subtle, false)
+	theme.Path = createStyle(config.Colors.Path, config.Styles.PathBold)
+	theme.Branch = createStyle(config.Colors.Branch, false)
+	theme.Remote = createStyle(config.Colors.Remote, false)
+	theme.Status = createStyle(config.Colors.Status, false)
+	theme.Stats = createStyle(config.Colors.Stats, false)
 
 	return theme, nil
 }
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"gogws/internal/git"
 	"gogws/internal/gws"
@@ -12,7 +15,8 @@ import (
 )
 
 type Renderer struct {
-	theme theme.Theme
+	theme      theme.Theme
+	showCached bool
 }
 
 func NewRenderer() *Renderer {
@@ -21,6 +25,10 @@ func NewRenderer() *Renderer {
 	}
 }
 
+func (r *Renderer) SetShowCached(show bool) {
+	r.showCached = show
+}
+
 func (r *Renderer) RenderHeader(title string) string {
 	return r.theme.HeaderBox.Render(" " + title + " ")
 }
@@ -144,6 +152,11 @@ func (r *Renderer) renderRepo(status git.RepositoryStatus) string {
 	}
 
 	header := fmt.Sprintf("  %s %s", icon, r.theme.Path.Render(status.Path))
+	width := len(status.Path)
+	if r.showCached && status.Cached {
+		header += " " + r.theme.Subtle.Render("(cached)")
+		width += len(" (cached)")
+	}
 
 	var workingTreeStatus []string
 	if status.Uncommitted > 0 {
@@ -152,9 +165,16 @@ func (r *Renderer) renderRepo(status git.RepositoryStatus) string {
 	if status.Untracked > 0 {
 		workingTreeStatus = append(workingTreeStatus, r.theme.Info.Render(fmt.Sprintf("%d untracked", status.Untracked)))
 	}
+	if !status.OnDefaultBranch() {
+		offDefault := fmt.Sprintf("not on %s", status.DefaultBranch)
+		if status.BehindDefault > 0 {
+			offDefault += fmt.Sprintf(" (%d behind)", status.BehindDefault)
+		}
+		workingTreeStatus = append(workingTreeStatus, r.theme.Subtle.Render(offDefault))
+	}
 
 	if len(workingTreeStatus) > 0 {
-		padding := 40 - len(status.Path)
+		padding := 40 - width
 		if padding < 2 {
 			padding = 2
 		}
@@ -351,12 +371,207 @@ func (r *Renderer) RenderWorkspacesList(workspaces []*gws.Workspace) string {
 	return output.String()
 }
 
+func (r *Renderer) RenderLog(entries []git.LogEntry) string {
+	repoWidth := 0
+	for _, e := range entries {
+		repoWidth = max(repoWidth, len(e.Repo))
+	}
+
+	var output strings.Builder
+	for _, e := range entries {
+		hash := e.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		output.WriteString(fmt.Sprintf("  %s  %s  %s  %s %s\n",
+			r.theme.Subtle.Render(e.Date.Local().Format("2006-01-02 15:04")),
+			r.theme.Path.Render(padRight(e.Repo, repoWidth)),
+			r.theme.Branch.Render(hash),
+			e.Subject,
+			r.theme.Subtle.Render("("+e.Author+")"),
+		))
+	}
+
+	return output.String()
+}
+
+func (r *Renderer) RenderDiffStat(files []git.DiffFile) string {
+	const barWidth = 40
+
+	names := make([]string, len(files))
+	nameWidth, maxChanges, added, deleted := 0, 0, 0, 0
+	for i, f := range files {
+		names[i] = path.Join(f.Repo, f.Path)
+		if f.OldPath != "" {
+			names[i] = path.Join(f.Repo, f.OldPath) + " => " + f.Path
+		}
+		nameWidth = max(nameWidth, len(names[i]))
+		maxChanges = max(maxChanges, f.Added+f.Deleted)
+		added += f.Added
+		deleted += f.Deleted
+	}
+
+	countWidth := max(len(strconv.Itoa(maxChanges)), 3)
+
+	var output strings.Builder
+	for i, f := range files {
+		if f.Binary {
+			output.WriteString(fmt.Sprintf(" %s | %s\n", r.theme.Path.Render(padRight(names[i], nameWidth)), r.theme.Subtle.Render(fmt.Sprintf("%*s", countWidth, "Bin"))))
+			continue
+		}
+
+		plus, minus := f.Added, f.Deleted
+		if maxChanges > barWidth {
+			plus = (f.Added*barWidth + maxChanges - 1) / maxChanges
+			minus = (f.Deleted*barWidth + maxChanges - 1) / maxChanges
+		}
+		output.WriteString(fmt.Sprintf(" %s | %*d %s%s\n",
+			r.theme.Path.Render(padRight(names[i], nameWidth)),
+			countWidth, f.Added+f.Deleted,
+			r.theme.Success.Render(strings.Repeat("+", plus)),
+			r.theme.Error.Render(strings.Repeat("-", minus)),
+		))
+	}
+
+	summary := fmt.Sprintf(" %d file%s changed", len(files), pluralSuffix(len(files)))
+	if added > 0 {
+		summary += fmt.Sprintf(", %d insertion%s(+)", added, pluralSuffix(added))
+	}
+	if deleted > 0 {
+		summary += fmt.Sprintf(", %d deletion%s(-)", deleted, pluralSuffix(deleted))
+	}
+	output.WriteString(summary + "\n")
+	return output.String()
+}
+
+func (r *Renderer) RenderManifestEdits(edits []gws.ManifestEdit) string {
+	var output strings.Builder
+	file := ""
+	for _, e := range edits {
+		if e.File != file {
+			file = e.File
+			output.WriteString(r.theme.Path.Render(file) + "\n")
+		}
+		output.WriteString(r.theme.Subtle.Render(fmt.Sprintf("%5d ", e.Line)) + r.theme.Error.Render("- "+e.Old) + "\n")
+		output.WriteString(r.theme.Subtle.Render(fmt.Sprintf("%5d ", e.Line)) + r.theme.Success.Render("+ "+e.New) + "\n")
+	}
+	return output.String()
+}
+
+func (r *Renderer) RenderGrep(matches []git.GrepMatch) string {
+	var output strings.Builder
+	for _, m := range matches {
+		output.WriteString(fmt.Sprintf("%s%s%s\n",
+			r.theme.Path.Render(path.Join(m.Repo, m.Path)),
+			r.theme.Subtle.Render(fmt.Sprintf(":%d:%d:", m.Line, m.Column)),
+			m.Text,
+		))
+	}
+	return output.String()
+}
+
+func (r *Renderer) RenderApply(results []git.ApplyResult, dryRun bool) string {
+	repoWidth := 0
+	for _, res := range results {
+		repoWidth = max(repoWidth, len(res.Repo))
+	}
+
+	var output strings.Builder
+	for _, res := range results {
+		repo := r.theme.Path.Render(padRight(res.Repo, repoWidth))
+
+		var line string
+		switch res.Outcome {
+		case git.ApplyClean:
+			line = r.theme.Success.Render(r.theme.Icons.Success) + " " + repo + "  "
+			switch {
+			case dryRun:
+				line += "applies cleanly"
+			case res.Commit != "":
+				line += fmt.Sprintf("applied on %s as %s", r.theme.Branch.Render(res.Branch), r.theme.Branch.Render(res.Commit))
+			default:
+				line += fmt.Sprintf("applied on %s (staged)", r.theme.Branch.Render(res.Branch))
+			}
+		case git.ApplyConflicts:
+			line = r.theme.Warning.Render(r.theme.Icons.Warning) + " " + repo + "  " +
+				fmt.Sprintf("conflicts on %s: %s", r.theme.Branch.Render(res.Branch), strings.Join(res.Conflicts, ", "))
+		case git.ApplyNotApplicable:
+			line = r.theme.Subtle.Render(r.theme.Icons.Info + " " + padRight(res.Repo, repoWidth) + "  not applicable")
+			if res.Reason != "" {
+				line += r.theme.Subtle.Render(": " + res.Reason)
+			}
+		case git.ApplySkipped:
+			line = r.theme.Subtle.Render(r.theme.Icons.Info + " " + padRight(res.Repo, repoWidth) + "  skipped: " + res.Reason)
+		default:
+			line = r.theme.Error.Render(r.theme.Icons.Error) + " " + repo + "  " + r.theme.Error.Render(res.Reason)
+		}
+		output.WriteString(line + "\n")
+	}
+
+	return output.String()
+}
+
+func (r *Renderer) RenderIncoming(repos []git.RepoRefChanges) string {
+	var output strings.Builder
+	for _, repo := range repos {
+		output.WriteString(r.theme.Path.Render(repo.Repo) + "\n")
+		for _, c := range repo.Changes {
+			output.WriteString("  " + r.renderRefChange(c) + "\n")
+			for _, commit := range c.Commits {
+				output.WriteString(fmt.Sprintf("      %s %s %s\n",
+					r.theme.Branch.Render(commit.Hash),
+					commit.Subject,
+					r.theme.Subtle.Render("("+commit.Author+")"),
+				))
+			}
+			if more := c.Count - len(c.Commits); more > 0 && len(c.Commits) > 0 {
+				output.WriteString(r.theme.Subtle.Render(fmt.Sprintf("      ... and %d more", more)) + "\n")
+			}
+		}
+	}
+
+	return output.String()
+}
+
+func (r *Renderer) renderRefChange(c git.RefChange) string {
+	name := r.theme.Remote.Render(c.Name())
+	if c.IsTag() {
+		name = "tag " + r.theme.Branch.Render(c.Name())
+	}
+
+	switch c.Kind {
+	case git.RefNew:
+		if c.Count > 0 {
+			return r.theme.Success.Render("new") + "      " + name + " " + r.theme.Subtle.Render(fmt.Sprintf("(%d not on the default branch)", c.Count))
+		}
+		return r.theme.Success.Render("new") + "      " + name
+	case git.RefDeleted:
+		return r.theme.Error.Render("deleted") + "  " + name
+	}
+
+	if c.IsTag() {
+		return r.theme.Warning.Render("moved") + "    " + name
+	}
+
+	switch c.Kind {
+	case git.RefForced:
+		return r.theme.Warning.Render("forced") + "   " + name + " " + r.theme.Subtle.Render(fmt.Sprintf("(rewritten, %d new)", c.Count))
+	default:
+		return r.theme.Ahead.Render("updated") + "  " + name + " " + r.theme.Subtle.Render(fmt.Sprintf("(%d new)", c.Count))
+	}
+}
+
 func (r *Renderer) RenderProgress(current, total int, repoPath string) string {
 	percentage := float64(current) / float64(total) * 100
 	return fmt.Sprintf("[%d/%d] %.0f%% - %s",
 		current, total, percentage, r.theme.Path.Render(repoPath))
 }
 
+func (r *Renderer) RenderWorker(frame, repoPath string, elapsed time.Duration) string {
+	return fmt.Sprintf("%s %s %s",
+		r.theme.Info.Render(frame), r.theme.Path.Render(repoPath), r.theme.Subtle.Render(fmt.Sprintf("%.1fs", elapsed.Seconds())))
+}
+
 func (r *Renderer) RenderSuccess(message string) string {
 	return r.theme.Success.Render(r.theme.Icons.Success + " " + message)
 }
@@ -375,7 +590,7 @@ func (r *Renderer) RenderWarning(message string) string {
 
 func (r *Renderer) RenderConfigValue(key string, value interface{}, source string) string {
 	sourceStyle := r.theme.Subtle
-	if source == "env" {
+	if strings.HasPrefix(source, "env") {
 		sourceStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Italic(true)
 	}
 
@@ -386,6 +601,89 @@ func (r *Renderer) RenderConfigValue(key string, value interface{}, source strin
 	)
 }
 
+func (r *Renderer) RenderColumns(columns []string, rows [][]string) string {
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = lipgloss.Width(column)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], lipgloss.Width(value))
+		}
+	}
+
+	var output strings.Builder
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = r.padCell(r.theme.Subtitle.Render(strings.ToUpper(column)), widths[i], i == len(columns)-1)
+	}
+	output.WriteString(strings.Join(header, "  "))
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = r.padCell(r.columnStyle(columns[i], value).Render(value), widths[i], i == len(row)-1)
+		}
+		output.WriteString("\n")
+		output.WriteString(strings.Join(cells, "  "))
+	}
+
+	return output.String()
+}
+
+func (r *Renderer) padCell(rendered string, width int, last bool) string {
+	if last {
+		return rendered
+	}
+	return rendered + strings.Repeat(" ", max(0, width-lipgloss.Width(rendered)))
+}
+
+func (r *Renderer) columnStyle(column, value string) lipgloss.Style {
+	if value == "-" || value == "0" || value == "no" {
+		return r.theme.Subtle
+	}
+
+	switch column {
+	case "path":
+		return r.theme.Path
+	case "branch":
+		if value == "(missing)" {
+			return r.theme.Error
+		}
+		return r.theme.Branch
+	case "ahead", "ahead-default":
+		return r.theme.Ahead
+	case "behind", "behind-default":
+		return r.theme.Behind
+	case "default":
+		if strings.HasSuffix(value, " (off)") {
+			return r.theme.Warning
+		}
+		return r.theme.Branch
+	case "dirty", "uncommitted", "untracked":
+		return r.theme.Warning
+	case "remote":
+		return r.theme.Remote
+	case "last-commit", "commit", "author":
+		return r.theme.Subtle
+	case "action":
+		if strings.HasPrefix(value, "keep") {
+			return r.theme.Subtle
+		}
+		return r.theme.Warning
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+func pluralSuffix(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
 func padRight(s string, length int) string {
 	if len(s) >= length {
 		return s
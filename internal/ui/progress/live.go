@@ -0,0 +1,200 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"gogws/internal/engine"
+	"gogws/internal/ui/cli"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const (
+	refreshInterval = 100 * time.Millisecond
+	barWidth        = 30
+)
+
+type worker struct {
+	name  string
+	start time.Time
+}
+
+type Live struct {
+	mu          sync.Mutex
+	w           io.Writer
+	renderer    *cli.Renderer
+	interactive bool
+	width       int
+
+	total     int
+	completed int
+	active    []worker
+	failures  []string
+	frame     int
+	lines     int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewLive(w io.Writer, renderer *cli.Renderer, interactive bool, width int) *Live {
+	if width <= 0 {
+		width = 80
+	}
+	return &Live{
+		w:           w,
+		renderer:    renderer,
+		interactive: interactive,
+		width:       width,
+	}
+}
+
+func (l *Live) Observer() engine.Observer {
+	return engine.Observer{
+		OnRunStart:  l.start,
+		OnRunFinish: func(*engine.ExecuteResult) { l.finish() },
+		OnStart:     l.started,
+		OnComplete:  l.complete,
+	}
+}
+
+func (l *Live) start(total int) {
+	l.mu.Lock()
+	l.total = total
+	l.completed = 0
+	l.active = nil
+	l.failures = nil
+	l.lines = 0
+	l.mu.Unlock()
+
+	if !l.interactive {
+		return
+	}
+
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	fmt.Fprint(l.w, "\x1b[?25l")
+
+	go func() {
+		defer close(l.done)
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				l.mu.Lock()
+				l.frame = (l.frame + 1) % len(spinnerFrames)
+				l.redraw()
+				l.mu.Unlock()
+			}
+		}
+	}()
+}
+
+func (l *Live) finish() {
+	if !l.interactive {
+		return
+	}
+
+	close(l.stop)
+	<-l.done
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clear()
+	fmt.Fprint(l.w, "\x1b[?25h")
+}
+
+func (l *Live) started(cmd engine.RepoCommand) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active = append(l.active, worker{name: cmd.RepoName, start: time.Now()})
+	if l.interactive {
+		l.redraw()
+	}
+}
+
+func (l *Live) complete(result engine.Result) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	name := result.Command.RepoName
+	for i, w := range l.active {
+		if w.name == name {
+			l.active = append(l.active[:i], l.active[i+1:]...)
+			break
+		}
+	}
+	l.completed++
+
+	if result.IsFailure() {
+		l.failures = append(l.failures, fmt.Sprintf("%s: %s", name, failureMessage(result)))
+	}
+
+	if l.interactive {
+		l.redraw()
+		return
+	}
+
+	line := l.renderer.RenderProgress(l.completed, l.total, name)
+	switch {
+	case result.IsSkipped():
+		line += " skipped: " + result.SkipReason
+	case result.IsFailure():
+		line += " failed: " + failureMessage(result)
+	default:
+		line += fmt.Sprintf(" done (%s)", result.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintln(l.w, line)
+}
+
+func (l *Live) redraw() {
+	l.clear()
+
+	var lines []string
+	for _, f := range l.failures {
+		lines = append(lines, l.renderer.RenderError(f))
+	}
+
+	bar := New(l.total, barWidth)
+	bar.SetCurrent(l.completed)
+	lines = append(lines, bar.Render())
+
+	for _, w := range l.active {
+		lines = append(lines, "  "+l.renderer.RenderWorker(spinnerFrames[l.frame], w.name, time.Since(w.start)))
+	}
+
+	truncate := lipgloss.NewStyle().MaxWidth(l.width - 1)
+	for _, line := range lines {
+		fmt.Fprintln(l.w, truncate.Render(line))
+	}
+	l.lines = len(lines)
+}
+
+func (l *Live) clear() {
+	if l.lines == 0 {
+		return
+	}
+	fmt.Fprintf(l.w, "\x1b[%dA\r\x1b[J", l.lines)
+	l.lines = 0
+}
+
+func failureMessage(result engine.Result) string {
+	msg := strings.TrimSpace(result.Stderr)
+	if msg == "" && result.Error != nil {
+		msg = result.Error.Error()
+	}
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		msg = msg[:i]
+	}
+	return msg
+}
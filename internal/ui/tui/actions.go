@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gogws/internal/engine"
+	"gogws/internal/git"
+)
+
+type action struct {
+	Name string
+	Args []string
+}
+
+var actions = map[string]action{
+	"f": {Name: "fetch", Args: []string{"fetch", "--all"}},
+	"p": {Name: "ff", Args: []string{"pull", "--ff-only"}},
+	"P": {Name: "push", Args: []string{"push"}},
+	"s": {Name: "stash", Args: []string{"stash", "push"}},
+}
+
+type (
+	tickMsg     struct{}
+	statusesMsg map[string]git.RepositoryStatus
+
+	repoOutputMsg struct{ path, chunk string }
+	repoDoneMsg   struct{ result engine.Result }
+	actionDoneMsg struct {
+		name   string
+		result *engine.ExecuteResult
+	}
+	shellDoneMsg struct {
+		path string
+		err  error
+	}
+)
+
+func tick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+func refreshStatuses(entries []*entry, parallel int) tea.Cmd {
+	var mu sync.Mutex
+	statuses := make(statusesMsg, len(entries))
+
+	commands := make([]engine.RepoCommand, 0, len(entries))
+	for _, e := range entries {
+		if e.Header {
+			continue
+		}
+		dir, path, defaultBranch := e.Dir, e.Path, e.DefaultBranch
+		commands = append(commands, engine.NewCustomCommand(dir, path, func() (string, error) {
+			status := git.GetStatusWithDefault(dir, defaultBranch)
+			status.Path = path
+			mu.Lock()
+			statuses[path] = status
+			mu.Unlock()
+			return "", nil
+		}))
+	}
+
+	return func() tea.Msg {
+		engine.Execute(commands, engine.ExecuteOptions{Parallel: parallel})
+		return statuses
+	}
+}
+
+func runAction(a action, targets []*entry, parallel int, events chan<- tea.Msg) tea.Cmd {
+	commands := make([]engine.RepoCommand, 0, len(targets))
+	for _, e := range targets {
+		commands = append(commands, engine.NewGitCommand(e.Dir, e.Path, a.Args...))
+	}
+
+	return func() tea.Msg {
+		go func() {
+			result := engine.Execute(commands, engine.ExecuteOptions{
+				Parallel: parallel,
+				OnOutput: func(cmd engine.RepoCommand, stream, chunk string) {
+					events <- repoOutputMsg{path: cmd.RepoName, chunk: chunk}
+				},
+				OnComplete: func(r engine.Result) {
+					events <- repoDoneMsg{result: r}
+				},
+			})
+			events <- actionDoneMsg{name: a.Name, result: result}
+		}()
+		return nil
+	}
+}
+
+func waitForEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+func openShell(e *entry) tea.Cmd {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "sh"
+		if runtime.GOOS == "windows" {
+			shell = "cmd"
+		}
+	}
+
+	cmd := exec.Command(shell)
+	cmd.Dir = e.Dir
+	path := e.Path
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellDoneMsg{path: path, err: err}
+	})
+}
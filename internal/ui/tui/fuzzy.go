@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+)
+
+func fuzzyMatch(pattern, s string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return true
+	}
+
+	target := []rune(strings.ToLower(s))
+	i := 0
+	for _, p := range strings.ToLower(pattern) {
+		if unicode.IsSpace(p) {
+			continue
+		}
+		for i < len(target) && target[i] != p {
+			i++
+		}
+		if i == len(target) {
+			return false
+		}
+		i++
+	}
+	return true
+}
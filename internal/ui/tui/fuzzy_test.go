@@ -0,0 +1,25 @@
+package tui
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"", "services/api", true},
+		{"api", "services/api", true},
+		{"svapi", "services/api", true},
+		{"SvApi", "services/api", true},
+		{"sv api", "services/api", true},
+		{"ipa", "services/api", false},
+		{"web", "services/api", false},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,541 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"gogws/internal/gws"
+	"gogws/internal/theme"
+)
+
+type filterMode int
+
+const (
+	filterAll filterMode = iota
+	filterChanged
+	filterMissing
+)
+
+func (f filterMode) String() string {
+	switch f {
+	case filterChanged:
+		return "changed"
+	case filterMissing:
+		return "missing"
+	default:
+		return "all"
+	}
+}
+
+type Options struct {
+	Parallel        int
+	RefreshInterval time.Duration
+}
+
+type Model struct {
+	workspace *gws.Workspace
+	opts      Options
+	theme     theme.Theme
+
+	entries  []*entry
+	byPath   map[string]*entry
+	selected map[string]bool
+	cursor   int
+	offset   int
+
+	filter    filterMode
+	search    textinput.Model
+	searching bool
+
+	output viewport.Model
+	events chan tea.Msg
+
+	refreshing bool
+	pending    bool
+	running    int
+	message    string
+	width      int
+	height     int
+}
+
+func New(ws *gws.Workspace, opts Options) Model {
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = 5 * time.Second
+	}
+
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "fuzzy search"
+
+	entries := buildTree(ws)
+	byPath := make(map[string]*entry, len(entries))
+	for _, e := range entries {
+		if !e.Header {
+			byPath[e.Path] = e
+		}
+	}
+
+	m := Model{
+		workspace:  ws,
+		opts:       opts,
+		theme:      theme.GetTheme(),
+		entries:    entries,
+		byPath:     byPath,
+		selected:   make(map[string]bool),
+		search:     search,
+		output:     viewport.New(0, 0),
+		events:     make(chan tea.Msg, 256),
+		refreshing: true,
+	}
+	m.cursor = m.firstSelectable(m.visible())
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		refreshStatuses(m.entries, m.opts.Parallel),
+		waitForEvent(m.events),
+		tick(m.opts.RefreshInterval),
+	)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case tickMsg:
+		if m.refreshing || m.running > 0 {
+			return m, tick(m.opts.RefreshInterval)
+		}
+		m.refreshing = true
+		return m, tea.Batch(refreshStatuses(m.entries, m.opts.Parallel), tick(m.opts.RefreshInterval))
+
+	case statusesMsg:
+		for path, status := range msg {
+			if e, ok := m.byPath[path]; ok {
+				e.Status = status
+				e.Loaded = true
+			}
+		}
+		m.refreshing = false
+		m.clampCursor()
+		if m.pending {
+			m.pending = false
+			return m, m.refresh()
+		}
+		return m, nil
+
+	case repoOutputMsg:
+		if e, ok := m.byPath[msg.path]; ok {
+			e.appendOutput(msg.chunk)
+			m.syncOutput()
+		}
+		return m, waitForEvent(m.events)
+
+	case repoDoneMsg:
+		if e, ok := m.byPath[msg.result.Command.RepoName]; ok {
+			e.Running = ""
+			switch {
+			case msg.result.IsFailure():
+				e.appendOutput(fmt.Sprintf("\n✗ failed: %v\n", msg.result.Error))
+			default:
+				e.appendOutput(fmt.Sprintf("\n✓ done in %s\n", msg.result.Duration.Round(time.Millisecond)))
+			}
+			m.syncOutput()
+		}
+		return m, waitForEvent(m.events)
+
+	case actionDoneMsg:
+		m.running--
+		m.message = fmt.Sprintf("%s: %d succeeded, %d failed", msg.name, msg.result.SuccessCount(), msg.result.FailedCount())
+		return m, tea.Batch(waitForEvent(m.events), m.refresh())
+
+	case shellDoneMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("shell in %s: %v", msg.path, msg.err)
+		}
+		return m, m.refresh()
+
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateKeys(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.search.SetValue("")
+		m.search.Blur()
+		m.searching = false
+	case "enter":
+		m.search.Blur()
+		m.searching = false
+	default:
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		m.clampCursor()
+		return m, cmd
+	}
+	m.clampCursor()
+	return m, nil
+}
+
+func (m Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visible := m.visible()
+
+	switch key := msg.String(); key {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		m.move(visible, -1)
+	case "down", "j":
+		m.move(visible, 1)
+	case "/":
+		m.searching = true
+		return m, m.search.Focus()
+	case "esc":
+		m.search.SetValue("")
+		m.selected = make(map[string]bool)
+		m.clampCursor()
+	case "tab":
+		m.filter = (m.filter + 1) % 3
+		m.clampCursor()
+	case " ":
+		if e := m.current(visible); e != nil {
+			m.selected[e.Path] = !m.selected[e.Path]
+			if !m.selected[e.Path] {
+				delete(m.selected, e.Path)
+			}
+		}
+		m.move(visible, 1)
+	case "a":
+		m.toggleAll(visible)
+	case "r":
+		return m, m.refresh()
+	case "o", "enter":
+		if e := m.current(visible); e != nil && !e.Missing {
+			return m, openShell(e)
+		}
+	default:
+		if a, ok := actions[key]; ok {
+			return m.run(a, visible)
+		}
+	}
+
+	m.syncOutput()
+	return m, nil
+}
+
+func (m *Model) refresh() tea.Cmd {
+	if m.refreshing {
+		m.pending = true
+		return nil
+	}
+	m.refreshing = true
+	return refreshStatuses(m.entries, m.opts.Parallel)
+}
+
+func (m Model) run(a action, visible []*entry) (tea.Model, tea.Cmd) {
+	var targets []*entry
+	for _, e := range m.entries {
+		if m.selected[e.Path] && !e.Missing {
+			targets = append(targets, e)
+		}
+	}
+	if len(targets) == 0 {
+		if e := m.current(visible); e != nil && !e.Missing {
+			targets = append(targets, e)
+		}
+	}
+	if len(targets) == 0 {
+		m.message = "nothing to " + a.Name
+		return m, nil
+	}
+
+	for _, e := range targets {
+		e.Running = a.Name
+		e.Output = []string{fmt.Sprintf("$ git %s\n", strings.Join(a.Args, " "))}
+	}
+	m.running++
+	m.message = fmt.Sprintf("%s: running on %d repositories", a.Name, len(targets))
+	m.syncOutput()
+	return m, runAction(a, targets, m.opts.Parallel, m.events)
+}
+
+func (m *Model) visible() []*entry {
+	query := m.search.Value()
+	plain := query == "" && m.filter == filterAll
+
+	var rows []*entry
+	for _, e := range m.entries {
+		if e.Header {
+			if plain {
+				rows = append(rows, e)
+			}
+			continue
+		}
+		if !fuzzyMatch(query, e.Path) {
+			continue
+		}
+		switch m.filter {
+		case filterChanged:
+			if !e.changed() || e.Missing {
+				continue
+			}
+		case filterMissing:
+			if !e.Missing {
+				continue
+			}
+		}
+		rows = append(rows, e)
+	}
+	return rows
+}
+
+func (m *Model) current(visible []*entry) *entry {
+	if m.cursor < 0 || m.cursor >= len(visible) || visible[m.cursor].Header {
+		return nil
+	}
+	return visible[m.cursor]
+}
+
+func (m *Model) firstSelectable(visible []*entry) int {
+	for i, e := range visible {
+		if !e.Header {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *Model) move(visible []*entry, delta int) {
+	for i := m.cursor + delta; i >= 0 && i < len(visible); i += delta {
+		if !visible[i].Header {
+			m.cursor = i
+			break
+		}
+	}
+	m.scroll(len(visible))
+}
+
+func (m *Model) clampCursor() {
+	visible := m.visible()
+	if m.cursor >= len(visible) || m.cursor < 0 || (len(visible) > 0 && visible[m.cursor].Header) {
+		m.cursor = m.firstSelectable(visible)
+	}
+	m.scroll(len(visible))
+	m.syncOutput()
+}
+
+func (m *Model) scroll(total int) {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	if m.offset > total-height {
+		m.offset = max(0, total-height)
+	}
+}
+
+func (m *Model) toggleAll(visible []*entry) {
+	all := true
+	for _, e := range visible {
+		if !e.Header && !m.selected[e.Path] {
+			all = false
+			break
+		}
+	}
+	for _, e := range visible {
+		if e.Header {
+			continue
+		}
+		if all {
+			delete(m.selected, e.Path)
+		} else {
+			m.selected[e.Path] = true
+		}
+	}
+}
+
+func (m *Model) resize() {
+	m.output.Width = m.width - m.listWidth() - 4
+	m.output.Height = m.listHeight() - 1
+	m.search.Width = m.listWidth() - 4
+	m.syncOutput()
+}
+
+func (m *Model) listWidth() int {
+	return max(30, m.width*11/20)
+}
+
+func (m *Model) listHeight() int {
+	return max(1, m.height-6)
+}
+
+func (m *Model) syncOutput() {
+	e := m.current(m.visible())
+	if e == nil {
+		m.output.SetContent("")
+		return
+	}
+	m.output.SetContent(strings.Join(e.Output, ""))
+	m.output.GotoBottom()
+}
+
+func (m Model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	visible := m.visible()
+	listWidth := m.listWidth()
+	height := m.listHeight()
+
+	var rows []string
+	for i := m.offset; i < len(visible) && i < m.offset+height; i++ {
+		rows = append(rows, m.renderRow(visible[i], i == m.cursor, listWidth-2))
+	}
+	if len(visible) == 0 {
+		rows = append(rows, m.theme.Subtle.Render("no repositories match"))
+	}
+
+	pane := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240"))
+	list := pane.Width(listWidth - 2).Height(height).Render(strings.Join(rows, "\n"))
+
+	title := "output"
+	if e := m.current(visible); e != nil {
+		title = e.Path
+	}
+	output := pane.Width(m.width - listWidth - 2).Height(height).Render(
+		m.theme.Subtitle.Render(title) + "\n" + m.output.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.renderHeader(),
+		lipgloss.JoinHorizontal(lipgloss.Top, list, output),
+		m.renderFooter(),
+	)
+}
+
+func (m Model) renderHeader() string {
+	parts := []string{
+		m.theme.Title.Render("gogws · " + m.workspace.Name),
+		m.theme.Subtle.Render("filter: " + m.filter.String()),
+	}
+	if len(m.selected) > 0 {
+		parts = append(parts, m.theme.Info.Render(fmt.Sprintf("%d selected", len(m.selected))))
+	}
+	if m.refreshing {
+		parts = append(parts, m.theme.Subtle.Render("refreshing…"))
+	}
+	if m.message != "" {
+		parts = append(parts, m.message)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m Model) renderFooter() string {
+	search := m.search.View()
+	if !m.searching && m.search.Value() == "" {
+		search = m.theme.Subtle.Render("/ search")
+	}
+	help := m.theme.Subtle.Render("↑↓ move · space select · a all · tab filter · f fetch · p ff · P push · s stash · o shell · r refresh · q quit")
+	return search + "\n" + help
+}
+
+func (m Model) renderRow(e *entry, active bool, width int) string {
+	indent := strings.Repeat("  ", e.Depth)
+
+	if e.Header {
+		label := indent + m.theme.Icons.Workspace + " " + e.Path + "/"
+		if e.Missing {
+			label += " (missing)"
+		}
+		return m.theme.Subtitle.Render(label)
+	}
+
+	mark := "[ ]"
+	if m.selected[e.Path] {
+		mark = "[x]"
+	}
+
+	cursor := " "
+	if active {
+		cursor = "›"
+	}
+
+	line := fmt.Sprintf("%s%s %s %s %s", cursor, indent, mark, m.statusIcon(e), m.theme.Path.Render(e.Path))
+	if details := m.statusDetails(e); details != "" {
+		line += "  " + details
+	}
+	if e.Running != "" {
+		line += "  " + m.theme.Info.Render(e.Running+"…")
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
+
+func (m Model) statusIcon(e *entry) string {
+	switch {
+	case e.Missing || (e.Loaded && !e.Status.Exists):
+		return m.theme.Error.Render(m.theme.Icons.Error)
+	case !e.Loaded:
+		return m.theme.Subtle.Render(m.theme.Icons.Pending)
+	case e.Status.Error != nil:
+		return m.theme.Error.Render("!")
+	case !e.Status.Clean:
+		return m.theme.Warning.Render("●")
+	default:
+		return m.theme.Success.Render(m.theme.Icons.Success)
+	}
+}
+
+func (m Model) statusDetails(e *entry) string {
+	if e.Missing {
+		return m.theme.Subtle.Render("not cloned")
+	}
+	if !e.Loaded || !e.Status.Exists {
+		return ""
+	}
+
+	parts := []string{m.theme.Branch.Render(e.Status.Branch)}
+	ahead, behind := e.Status.Ahead, e.Status.Behind
+	for _, b := range e.Status.Branches {
+		if b.IsCurrent {
+			ahead, behind = b.Ahead, b.Behind
+		}
+	}
+	if ahead > 0 {
+		parts = append(parts, m.theme.Ahead.Render(fmt.Sprintf("↑%d", ahead)))
+	}
+	if behind > 0 {
+		parts = append(parts, m.theme.Behind.Render(fmt.Sprintf("↓%d", behind)))
+	}
+	if n := e.Status.Uncommitted + e.Status.Untracked; n > 0 {
+		parts = append(parts, m.theme.Warning.Render(fmt.Sprintf("%d changed", n)))
+	}
+	if !e.Status.OnDefaultBranch() {
+		offDefault := "not on " + e.Status.DefaultBranch
+		if e.Status.BehindDefault > 0 {
+			offDefault += fmt.Sprintf(" (%d behind)", e.Status.BehindDefault)
+		}
+		parts = append(parts, m.theme.Subtle.Render(offDefault))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gogws/internal/engine"
+	"gogws/internal/gws"
+)
+
+func TestRefreshDoesNotOverlap(t *testing.T) {
+	var model tea.Model = New(&gws.Workspace{Name: "ws"}, Options{})
+
+	model, _ = model.Update(actionDoneMsg{name: "fetch", result: engine.NewExecuteResult()})
+	if m := model.(Model); !m.refreshing || !m.pending {
+		t.Fatalf("refresh requested during a refresh should be pending, got refreshing=%v pending=%v", m.refreshing, m.pending)
+	}
+
+	model, _ = model.Update(shellDoneMsg{path: "api"})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+
+	model, cmd := model.Update(statusesMsg{})
+	if m := model.(Model); !m.refreshing || m.pending || cmd == nil {
+		t.Fatalf("pending requests should start exactly one refresh, got refreshing=%v pending=%v", m.refreshing, m.pending)
+	}
+
+	model, cmd = model.Update(statusesMsg{})
+	if m := model.(Model); m.refreshing || cmd != nil {
+		t.Errorf("finished refresh should not schedule more work, got refreshing=%v cmd=%v", m.refreshing, cmd != nil)
+	}
+}
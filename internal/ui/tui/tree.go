@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"path/filepath"
+
+	"gogws/internal/git"
+	"gogws/internal/gws"
+)
+
+const maxOutputLines = 500
+
+type entry struct {
+	Path          string
+	Dir           string
+	DefaultBranch string
+	Depth         int
+	Header        bool
+	Missing       bool
+
+	Status  git.RepositoryStatus
+	Loaded  bool
+	Running string
+	Output  []string
+}
+
+func buildTree(ws *gws.Workspace) []*entry {
+	return appendWorkspace(nil, ws, ws.Root, "", 0)
+}
+
+func appendWorkspace(entries []*entry, ws *gws.Workspace, root, prefix string, depth int) []*entry {
+	for _, p := range ws.Projects {
+		entries = append(entries, &entry{
+			Path:          filepath.ToSlash(filepath.Join(prefix, p.Path)),
+			Dir:           filepath.Join(root, p.Path),
+			DefaultBranch: p.DefaultBranch,
+			Depth:         depth,
+			Missing:       !p.Exists,
+		})
+	}
+
+	for _, child := range ws.Children {
+		childPrefix := filepath.Join(prefix, child.Path)
+		entries = append(entries, &entry{
+			Path:    filepath.ToSlash(childPrefix),
+			Dir:     filepath.Join(root, child.Path),
+			Depth:   depth,
+			Header:  true,
+			Missing: !child.Exists,
+		})
+		if child.Exists && child.Root != "" {
+			entries = appendWorkspace(entries, child, child.Root, childPrefix, depth+1)
+		}
+	}
+
+	return entries
+}
+
+func (e *entry) appendOutput(chunk string) {
+	e.Output = append(e.Output, chunk)
+	if len(e.Output) > maxOutputLines {
+		e.Output = e.Output[len(e.Output)-maxOutputLines:]
+	}
+}
+
+func (e *entry) changed() bool {
+	if !e.Loaded || e.Header {
+		return false
+	}
+	if !e.Status.Exists || e.Status.Error != nil || !e.Status.Clean {
+		return true
+	}
+	for _, b := range e.Status.Branches {
+		if b.Ahead > 0 || b.Behind > 0 {
+			return true
+		}
+	}
+	return e.Status.Ahead > 0 || e.Status.Behind > 0
+}
@@ -0,0 +1,237 @@
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gogws/internal/git"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const DefaultDebounce = 300 * time.Millisecond
+
+type Repo struct {
+	Name string
+	Dir  string
+}
+
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	repos    []Repo
+	debounce time.Duration
+	changes  chan string
+	skip     map[string]bool
+	missing  map[string]bool
+}
+
+func New(repos []Repo, debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]Repo(nil), repos...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i].Dir) > len(sorted[j].Dir)
+	})
+
+	w := &Watcher{
+		fs:       fsw,
+		repos:    sorted,
+		debounce: debounce,
+		changes:  make(chan string, len(repos)),
+		skip:     make(map[string]bool),
+		missing:  make(map[string]bool),
+	}
+
+	for _, r := range repos {
+		if isRepo(r.Dir) {
+			w.addRepo(r.Dir)
+		} else {
+			w.missing[r.Dir] = true
+			w.watchTowards(r.Dir)
+		}
+	}
+
+	return w, nil
+}
+
+func (w *Watcher) Changes() <-chan string {
+	return w.changes
+}
+
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+func (w *Watcher) Run(ctx context.Context) {
+	pending := make(map[string]time.Time)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) && len(w.missing) > 0 {
+				if repos := w.appeared(event.Name); len(repos) > 0 {
+					for _, repo := range repos {
+						slog.Debug("Repository appeared", "repo", repo)
+						pending[repo] = time.Now()
+					}
+					timer.Reset(w.debounce)
+					continue
+				}
+			}
+			repo := w.repoFor(event.Name)
+			if repo == "" || ignored(event.Name) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if git.IsIgnored(filepath.Dir(event.Name), event.Name) {
+						w.skip[event.Name] = true
+						continue
+					}
+					w.addTree(event.Name)
+				}
+			}
+			slog.Debug("Filesystem change", "repo", repo, "path", event.Name, "op", event.Op.String())
+			pending[repo] = time.Now()
+			timer.Reset(w.debounce)
+
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			slog.Debug("Watcher error", "err", err)
+
+		case <-timer.C:
+			now := time.Now()
+			for repo, last := range pending {
+				if now.Sub(last) < w.debounce {
+					continue
+				}
+				delete(pending, repo)
+				select {
+				case w.changes <- repo:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if len(pending) > 0 {
+				timer.Reset(w.debounce)
+			}
+		}
+	}
+}
+
+func (w *Watcher) addRepo(dir string) {
+	gitDir := filepath.Join(dir, ".git")
+	if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
+		w.add(gitDir)
+		w.addTree(filepath.Join(gitDir, "refs"))
+	}
+	if dirs, err := git.IgnoredDirs(dir); err == nil {
+		for _, d := range dirs {
+			w.skip[filepath.Join(dir, filepath.FromSlash(d))] = true
+		}
+	}
+	w.addTree(dir)
+}
+
+func (w *Watcher) watchTowards(dir string) {
+	for d := dir; ; d = filepath.Dir(d) {
+		if info, err := os.Stat(d); err == nil && info.IsDir() {
+			w.add(d)
+			return
+		}
+		if filepath.Dir(d) == d {
+			return
+		}
+	}
+}
+
+func (w *Watcher) appeared(path string) []string {
+	var ready []string
+	for dir := range w.missing {
+		if path != dir && path != filepath.Join(dir, ".git") && !strings.HasPrefix(dir, path+string(filepath.Separator)) {
+			continue
+		}
+		if !isRepo(dir) {
+			w.watchTowards(dir)
+			if !isRepo(dir) {
+				continue
+			}
+		}
+		delete(w.missing, dir)
+		w.addRepo(dir)
+		for _, r := range w.repos {
+			if r.Dir == dir {
+				ready = append(ready, r.Name)
+			}
+		}
+	}
+	return ready
+}
+
+func (w *Watcher) addTree(root string) {
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if w.skip[path] {
+			return filepath.SkipDir
+		}
+		if path != root {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		w.add(path)
+		return nil
+	})
+}
+
+func (w *Watcher) add(path string) {
+	if err := w.fs.Add(path); err != nil {
+		slog.Debug("Failed to watch directory", "path", path, "err", err)
+	}
+}
+
+func (w *Watcher) repoFor(path string) string {
+	for _, r := range w.repos {
+		if w.missing[r.Dir] {
+			continue
+		}
+		if path == r.Dir || strings.HasPrefix(path, r.Dir+string(filepath.Separator)) {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+func isRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+func ignored(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".lock") || base == "FETCH_HEAD"
+}
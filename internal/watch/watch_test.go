@@ -0,0 +1,98 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherReportsChangedRepo(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, name, ".git", "refs", "heads"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, err := New([]Repo{
+		{Name: "api", Dir: filepath.Join(root, "api")},
+		{Name: "web", Dir: filepath.Join(root, "web")},
+	}, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	if err := os.WriteFile(filepath.Join(root, "web", ".git", "refs", "heads", "main"), []byte("abc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case name := <-w.Changes():
+		if name != "web" {
+			t.Errorf("expected change in web, got %s", name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "api", ".git", "index.lock"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case name := <-w.Changes():
+		t.Errorf("lock file should be ignored, got change in %s", name)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcherPicksUpNewRepo(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "api", ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New([]Repo{
+		{Name: "api", Dir: filepath.Join(root, "api")},
+		{Name: "libs/web", Dir: filepath.Join(root, "libs", "web")},
+	}, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	if err := os.MkdirAll(filepath.Join(root, "libs", "web", ".git", "refs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case name := <-w.Changes():
+		if name != "libs/web" {
+			t.Errorf("expected libs/web to appear, got %s", name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("new repository not reported")
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "libs", "web", "README.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case name := <-w.Changes():
+		if name != "libs/web" {
+			t.Errorf("expected change in libs/web, got %s", name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("change in new repository not reported")
+	}
+}